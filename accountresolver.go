@@ -0,0 +1,400 @@
+package fortniteapi
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultAccountType = "epic"
+
+// AccountMapping is the cached ID of an account and the names it was
+// looked up by.
+type AccountMapping struct {
+	ID string `json:"id"`
+
+	// Name is the Epic display name of the latest response.
+	Name string `json:"name"`
+
+	// Aliases are the other names that resolve to the account, such as
+	// console names and former Epic names.
+	Aliases    []AccountAlias `json:"aliases,omitempty"`
+	ResolvedAt time.Time      `json:"resolvedAt"`
+}
+
+type AccountAlias struct {
+	Name string `json:"name"`
+
+	// Enum: "epic", "psn", "xbl"
+	AccountType string `json:"accountType"`
+}
+
+// AccountRename is a change of Epic display name.
+type AccountRename struct {
+	ID      string
+	OldName string
+	NewName string
+}
+
+func (m AccountMapping) keys() []string {
+	var keys []string
+	if m.Name != "" {
+		keys = append(keys, accountKey(defaultAccountType, m.Name))
+	}
+
+	for _, alias := range m.Aliases {
+		keys = append(keys, accountKey(alias.AccountType, alias.Name))
+	}
+
+	return keys
+}
+
+// addAlias adds a name unless it already resolves to the account.
+func (m *AccountMapping) addAlias(name string, accountType string) {
+	key := accountKey(accountType, name)
+	if slices.Contains(m.keys(), key) {
+		return
+	}
+
+	m.Aliases = append(m.Aliases, AccountAlias{Name: name, AccountType: cmp.Or(accountType, defaultAccountType)})
+}
+
+// removeName removes a name from the account, including its Epic name,
+// which stays empty until the account is observed again.
+func (m *AccountMapping) removeName(key string) {
+	if m.Name != "" && accountKey(defaultAccountType, m.Name) == key {
+		m.Name = ""
+	}
+
+	m.removeAlias(key)
+}
+
+func (m *AccountMapping) removeAlias(key string) {
+	m.Aliases = slices.DeleteFunc(m.Aliases, func(alias AccountAlias) bool {
+		return accountKey(alias.AccountType, alias.Name) == key
+	})
+}
+
+type AccountStore interface {
+	LoadAccounts(ctx context.Context) ([]AccountMapping, error)
+	SaveAccounts(ctx context.Context, accounts []AccountMapping) error
+}
+
+// FileAccountStore keeps the account mappings in a JSON file.
+type FileAccountStore struct {
+	Path string
+}
+
+func (f *FileAccountStore) LoadAccounts(_ context.Context) ([]AccountMapping, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read accounts: %w", err)
+	}
+
+	var accounts []AccountMapping
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to decode accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (f *FileAccountStore) SaveAccounts(_ context.Context, accounts []AccountMapping) error {
+	data, err := json.Marshal(accounts)
+	if err != nil {
+		return fmt.Errorf("failed to encode accounts: %w", err)
+	}
+
+	if err := writeFileAtomic(f.Path, data); err != nil {
+		return fmt.Errorf("failed to write accounts: %w", err)
+	}
+
+	return nil
+}
+
+type AccountResolverOptions struct {
+	// Store persists the mappings, so a restart doesn't look every name up
+	// again. Mappings are only kept in memory without a store.
+	Store AccountStore
+
+	Clock Clock
+
+	// OnRename is called when a cached ID comes back with a different Epic
+	// name. The former name keeps resolving to the account, unless another
+	// account took it over in the meantime.
+	OnRename func(AccountRename)
+
+	// OnError is called when Observe fails to load or save the mappings.
+	OnError func(error)
+}
+
+// AccountResolver maps display names to account IDs. The first lookup of a
+// name goes through GetBRStatsByName, later ones through GetBRStatsByID,
+// which keeps working after the player renames. Names are cached as they
+// were looked up, so console names resolve too.
+type AccountResolver struct {
+	client  *Client
+	options AccountResolverOptions
+
+	mu      sync.Mutex
+	byID    map[string]AccountMapping
+	byName  map[string]string
+	loaded  bool
+	version uint64
+
+	// saveMu serializes saves, which run outside mu so lookups don't wait
+	// for the store. saved is the version of the last saved mappings.
+	saveMu sync.Mutex
+	saved  atomic.Uint64
+}
+
+func NewAccountResolver(client *Client, options AccountResolverOptions) *AccountResolver {
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	return &AccountResolver{
+		client:  client,
+		options: options,
+		byID:    make(map[string]AccountMapping),
+		byName:  make(map[string]string),
+	}
+}
+
+// Resolve returns the account ID of a display name. accountType defaults
+// to "epic".
+func (r *AccountResolver) Resolve(ctx context.Context, name string, accountType string) (string, error) {
+	id, ok, err := r.lookup(ctx, name, accountType)
+	if err != nil || ok {
+		return id, err
+	}
+
+	stats, err := r.client.GetBRStatsByName(ctx, name, &BRStatsByNameParams{AccountType: accountType})
+	if err != nil {
+		return "", err
+	}
+
+	r.Observe(ctx, name, accountType, stats)
+	return stats.Account.ID, nil
+}
+
+// GetBRStats returns the stats of a display name, looking the player up by
+// ID once the name is cached. After a rename the stats of the renamed
+// player are returned and OnRename is called.
+func (r *AccountResolver) GetBRStats(ctx context.Context, name string, accountType string, params *BRStatsByIDParams) (*BRStatsResponse, error) {
+	id, ok, err := r.lookup(ctx, name, accountType)
+	if err != nil {
+		return nil, err
+	}
+
+	if params == nil {
+		params = &BRStatsByIDParams{}
+	}
+
+	var stats *BRStatsResponse
+
+	if ok {
+		stats, err = r.client.GetBRStatsByID(ctx, id, params)
+	} else {
+		stats, err = r.client.GetBRStatsByName(ctx, name, &BRStatsByNameParams{
+			AccountType:   accountType,
+			TimeWindow:    params.TimeWindow,
+			Image:         params.Image,
+			ResponseFlags: params.ResponseFlags,
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	r.Observe(ctx, name, accountType, stats)
+	return stats, nil
+}
+
+// Observe records the ID and name of a stats response fetched elsewhere.
+// name and accountType are what the stats were looked up by, and name is
+// empty for lookups by ID.
+func (r *AccountResolver) Observe(ctx context.Context, name string, accountType string, stats *BRStatsResponse) {
+	if stats == nil || stats.Account.ID == "" {
+		return
+	}
+
+	r.mu.Lock()
+
+	if err := r.load(ctx); err != nil {
+		r.mu.Unlock()
+		r.reportError(err)
+
+		return
+	}
+
+	var rename *AccountRename
+
+	mapping, ok := r.byID[stats.Account.ID]
+	previous := mapping
+	previous.Aliases = slices.Clone(mapping.Aliases)
+
+	if ok && mapping.Name != "" && mapping.Name != stats.Account.Name {
+		rename = &AccountRename{
+			ID:      mapping.ID,
+			OldName: mapping.Name,
+			NewName: stats.Account.Name,
+		}
+
+		mapping.addAlias(mapping.Name, defaultAccountType)
+	}
+
+	mapping.ID = stats.Account.ID
+	mapping.Name = stats.Account.Name
+	mapping.ResolvedAt = r.options.Clock.Now()
+	mapping.removeAlias(accountKey(defaultAccountType, mapping.Name))
+
+	if name != "" {
+		mapping.addAlias(name, accountType)
+	}
+
+	r.set(mapping)
+
+	// Only the timestamp changes on most lookups, which isn't worth a write
+	// unless an earlier save failed.
+	if !ok || previous.Name != mapping.Name || !slices.Equal(previous.Aliases, mapping.Aliases) {
+		r.version++
+	}
+
+	var (
+		accounts []AccountMapping
+		version  uint64
+	)
+
+	if r.options.Store != nil && r.version > r.saved.Load() {
+		accounts, version = r.list(), r.version
+	}
+
+	r.mu.Unlock()
+
+	if accounts != nil {
+		if err := r.save(ctx, accounts, version); err != nil {
+			r.reportError(err)
+		}
+	}
+
+	if rename != nil && r.options.OnRename != nil {
+		r.options.OnRename(*rename)
+	}
+}
+
+// set stores a mapping and points its names at it. A name that resolved to
+// another account is removed from that account.
+func (r *AccountResolver) set(mapping AccountMapping) {
+	for _, key := range mapping.keys() {
+		if id, ok := r.byName[key]; ok && id != mapping.ID {
+			other := r.byID[id]
+			other.removeName(key)
+			r.byID[id] = other
+		}
+
+		r.byName[key] = mapping.ID
+	}
+
+	r.byID[mapping.ID] = mapping
+}
+
+// Mappings returns every cached mapping, sorted by ID.
+func (r *AccountResolver) Mappings() []AccountMapping {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.list()
+}
+
+func (r *AccountResolver) list() []AccountMapping {
+	mappings := make([]AccountMapping, 0, len(r.byID))
+	for _, mapping := range r.byID {
+		mapping.Aliases = slices.Clone(mapping.Aliases)
+		mappings = append(mappings, mapping)
+	}
+
+	slices.SortFunc(mappings, func(a, b AccountMapping) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return mappings
+}
+
+func (r *AccountResolver) lookup(ctx context.Context, name string, accountType string) (string, bool, error) {
+	if name == "" {
+		return "", false, emptyParamErr("name")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.load(ctx); err != nil {
+		return "", false, err
+	}
+
+	id, ok := r.byName[accountKey(accountType, name)]
+	return id, ok, nil
+}
+
+func (r *AccountResolver) load(ctx context.Context) error {
+	if r.loaded || r.options.Store == nil {
+		return nil
+	}
+
+	accounts, err := r.options.Store.LoadAccounts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mapping := range accounts {
+		r.set(mapping)
+	}
+
+	r.loaded = true
+	return nil
+}
+
+// save writes the mappings taken at version, unless newer ones were already
+// saved by a concurrent Observe.
+func (r *AccountResolver) save(ctx context.Context, accounts []AccountMapping, version uint64) error {
+	r.saveMu.Lock()
+	defer r.saveMu.Unlock()
+
+	if version <= r.saved.Load() {
+		return nil
+	}
+
+	if err := r.options.Store.SaveAccounts(ctx, accounts); err != nil {
+		return err
+	}
+
+	r.saved.Store(version)
+	return nil
+}
+
+func (r *AccountResolver) reportError(err error) {
+	if r.options.OnError != nil {
+		r.options.OnError(err)
+	}
+}
+
+func accountKey(accountType string, name string) string {
+	if accountType == "" {
+		accountType = defaultAccountType
+	}
+
+	return accountType + "/" + strings.ToLower(name)
+}
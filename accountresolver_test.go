@@ -0,0 +1,178 @@
+package fortniteapi
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AccountResolver_DetectsRenames(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "Player", BRStatsData{Wins: 1}))
+
+	var renames []AccountRename
+
+	resolver := NewAccountResolver(newTestStatsClient(t, server.handle(t)), AccountResolverOptions{
+		OnRename: func(rename AccountRename) { renames = append(renames, rename) },
+	})
+
+	id, err := resolver.Resolve(testCtx, "player", "")
+	require.NoError(t, err)
+	assert.Equal(t, "id1", id)
+
+	stats, err := resolver.GetBRStats(testCtx, "PLAYER", "epic", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Stats.All.Overall.Wins)
+	assert.Equal(t, 1, server.lookupsByName())
+
+	server.set(testStats("id1", "Renamed", BRStatsData{Wins: 2}))
+
+	stats, err = resolver.GetBRStats(testCtx, "player", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", stats.Account.Name)
+	assert.Equal(t, []AccountRename{{ID: "id1", OldName: "Player", NewName: "Renamed"}}, renames)
+
+	id, err = resolver.Resolve(testCtx, "renamed", "epic")
+	require.NoError(t, err)
+	assert.Equal(t, "id1", id)
+	assert.Equal(t, 1, server.lookupsByName())
+
+	// The former name stays an alias of the account.
+	for range 2 {
+		stats, err = resolver.GetBRStats(testCtx, "player", "epic", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", stats.Account.Name)
+	}
+
+	assert.Equal(t, 1, server.lookupsByName())
+	assert.Len(t, renames, 1)
+}
+
+func Test_AccountResolver_ConsoleNames(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "EpicName", BRStatsData{}))
+
+	// The API answers console lookups with the Epic name.
+	client := newTestStatsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") == "ConsoleName" {
+			r.URL.RawQuery = "name=EpicName"
+		}
+
+		server.handle(t)(w, r)
+	})
+
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	resolver := NewAccountResolver(client, AccountResolverOptions{Clock: newFakeClock(now)})
+
+	for range 2 {
+		stats, err := resolver.GetBRStats(testCtx, "ConsoleName", "psn", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "id1", stats.Account.ID)
+	}
+
+	assert.Equal(t, 1, server.lookupsByName())
+
+	id, err := resolver.Resolve(testCtx, "epicname", "")
+	require.NoError(t, err)
+	assert.Equal(t, "id1", id)
+	assert.Equal(t, 1, server.lookupsByName())
+
+	_, err = resolver.Resolve(testCtx, "consolename", "xbl")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	assert.Equal(t, []AccountMapping{{
+		ID:         "id1",
+		Name:       "EpicName",
+		Aliases:    []AccountAlias{{Name: "ConsoleName", AccountType: "psn"}},
+		ResolvedAt: now,
+	}}, resolver.Mappings())
+}
+
+func Test_AccountResolver_Store(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "Player", BRStatsData{}))
+
+	client := newTestStatsClient(t, server.handle(t))
+	store := &FileAccountStore{Path: filepath.Join(t.TempDir(), "accounts.json")}
+
+	_, err := NewAccountResolver(client, AccountResolverOptions{Store: store}).Resolve(testCtx, "Player", "psn")
+	require.NoError(t, err)
+
+	resolver := NewAccountResolver(client, AccountResolverOptions{Store: store})
+
+	id, err := resolver.Resolve(testCtx, "player", "psn")
+	require.NoError(t, err)
+	assert.Equal(t, "id1", id)
+	assert.Equal(t, 1, server.lookupsByName())
+	assert.Equal(t, []AccountAlias{{Name: "Player", AccountType: "psn"}}, resolver.Mappings()[0].Aliases)
+}
+
+func Test_AccountResolver_NameTakenOver(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "Player", BRStatsData{}))
+
+	resolver := NewAccountResolver(newTestStatsClient(t, server.handle(t)), AccountResolverOptions{})
+
+	_, err := resolver.Resolve(testCtx, "Player", "")
+	require.NoError(t, err)
+
+	// id1 renamed and id2 picked up the free name.
+	stats := testStats("id2", "Player", BRStatsData{})
+	resolver.Observe(testCtx, "Player", "", &stats)
+
+	mappings := resolver.Mappings()
+	require.Len(t, mappings, 2)
+	assert.Empty(t, mappings[0].Name)
+	assert.Equal(t, "Player", mappings[1].Name)
+
+	id, err := resolver.Resolve(testCtx, "player", "")
+	require.NoError(t, err)
+	assert.Equal(t, "id2", id)
+}
+
+type countingAccountStore struct {
+	FileAccountStore
+	saves atomic.Int32
+}
+
+func (c *countingAccountStore) SaveAccounts(ctx context.Context, accounts []AccountMapping) error {
+	c.saves.Add(1)
+	return c.FileAccountStore.SaveAccounts(ctx, accounts)
+}
+
+func Test_AccountResolver_SavesOnlyChanges(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "Player", BRStatsData{}))
+
+	store := &countingAccountStore{FileAccountStore: FileAccountStore{Path: filepath.Join(t.TempDir(), "accounts.json")}}
+	resolver := NewAccountResolver(newTestStatsClient(t, server.handle(t)), AccountResolverOptions{Store: store})
+
+	for range 3 {
+		_, err := resolver.GetBRStats(testCtx, "Player", "", nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), store.saves.Load())
+
+	server.set(testStats("id1", "Renamed", BRStatsData{}))
+
+	_, err := resolver.GetBRStats(testCtx, "Player", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), store.saves.Load())
+}
@@ -0,0 +1,253 @@
+package fortniteapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NormalizeAESKey converts a key in either "hex" or "aes" format to
+// lowercase hex without the 0x prefix, so keys can be compared regardless
+// of the format they were requested in.
+func NormalizeAESKey(key string) string {
+	key = strings.TrimSpace(key)
+
+	if trimmed, ok := strings.CutPrefix(strings.ToLower(key), "0x"); ok {
+		return trimmed
+	}
+
+	if _, err := hex.DecodeString(key); err == nil {
+		return strings.ToLower(key)
+	}
+
+	if decoded, err := base64.StdEncoding.DecodeString(key); err == nil {
+		return hex.EncodeToString(decoded)
+	}
+
+	return key
+}
+
+type AESEventType string
+
+const (
+	AESBuildChanged       AESEventType = "buildChanged"
+	AESMainKeyChanged     AESEventType = "mainKeyChanged"
+	AESDynamicKeyAdded    AESEventType = "dynamicKeyAdded"
+	AESDynamicKeyRemoved  AESEventType = "dynamicKeyRemoved"
+	AESDynamicKeyReplaced AESEventType = "dynamicKeyReplaced"
+)
+
+type AESEvent struct {
+	Type          AESEventType
+	Build         string
+	PreviousBuild string
+	MainKey       string
+
+	// DynamicKey is set for dynamic key events. Previous holds the old key
+	// when it was replaced.
+	DynamicKey AESDynamicKey
+	Previous   AESDynamicKey
+}
+
+type AESBuildRecord struct {
+	Build     string    `json:"build"`
+	MainKey   string    `json:"mainKey"`
+	FirstSeen time.Time `json:"firstSeen"`
+}
+
+type AESPakRecord struct {
+	PakGUID      string    `json:"pakGuid"`
+	PakFilename  string    `json:"pakFilename"`
+	Key          string    `json:"key"`
+	FirstBuild   string    `json:"firstBuild"`
+	FirstSeen    time.Time `json:"firstSeen"`
+	RemovedBuild string    `json:"removedBuild,omitempty"`
+}
+
+// AESHistory records every build and dynamic pak the watcher has seen.
+type AESHistory struct {
+	Builds []AESBuildRecord        `json:"builds"`
+	Paks   map[string]AESPakRecord `json:"paks"`
+}
+
+// FirstBuildForPak returns the build that first exposed the pak.
+func (h *AESHistory) FirstBuildForPak(pakGUID string) (string, bool) {
+	record, ok := h.Paks[pakGUID]
+	return record.FirstBuild, ok
+}
+
+type AESWatcherOptions struct {
+	// Enum: "aes", "hex"
+	//
+	// Default: "hex"
+	KeyFormat string
+
+	// Interval is the time between two polls in Run.
+	//
+	// Default: 5 minutes
+	Interval time.Duration
+
+	Clock   Clock
+	OnError func(error)
+}
+
+type AESWatcher struct {
+	client  *Client
+	options AESWatcherOptions
+
+	// pollMu serializes polls, so mu is only held to update the keys and
+	// history and History doesn't wait for the network.
+	pollMu sync.Mutex
+
+	mu       sync.Mutex
+	previous *AESKeyResponse
+	history  AESHistory
+}
+
+func NewAESWatcher(client *Client, options AESWatcherOptions) *AESWatcher {
+	if options.KeyFormat == "" {
+		options.KeyFormat = "hex"
+	}
+
+	if options.Interval <= 0 {
+		options.Interval = 5 * time.Minute
+	}
+
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	return &AESWatcher{
+		client:  client,
+		options: options,
+		history: AESHistory{Paks: make(map[string]AESPakRecord)},
+	}
+}
+
+// Load seeds the watcher with the last seen keys and history.
+func (w *AESWatcher) Load(previous *AESKeyResponse, history AESHistory) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if history.Paks == nil {
+		history.Paks = make(map[string]AESPakRecord)
+	}
+
+	w.previous = previous
+	w.history = history
+}
+
+func (w *AESWatcher) History() AESHistory {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return AESHistory{
+		Builds: slices.Clone(w.history.Builds),
+		Paks:   maps.Clone(w.history.Paks),
+	}
+}
+
+func (w *AESWatcher) Poll(ctx context.Context) ([]AESEvent, error) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	current, err := w.client.GetAESKey(ctx, &AESKeyParams{KeyFormat: w.options.KeyFormat})
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	previous := w.previous
+	if previous == nil {
+		previous = &AESKeyResponse{}
+	}
+
+	now := w.options.Clock.Now()
+	base := AESEvent{Build: current.Build, PreviousBuild: previous.Build, MainKey: current.MainKey}
+
+	var events []AESEvent
+
+	if current.Build != previous.Build {
+		event := base
+		event.Type = AESBuildChanged
+		events = append(events, event)
+
+		w.history.Builds = append(w.history.Builds, AESBuildRecord{Build: current.Build, MainKey: current.MainKey, FirstSeen: now})
+	}
+
+	if NormalizeAESKey(current.MainKey) != NormalizeAESKey(previous.MainKey) {
+		event := base
+		event.Type = AESMainKeyChanged
+		events = append(events, event)
+	}
+
+	old := make(map[string]AESDynamicKey, len(previous.DynamicKeys))
+	for _, key := range previous.DynamicKeys {
+		old[key.PakGUID] = key
+	}
+
+	for _, key := range current.DynamicKeys {
+		before, ok := old[key.PakGUID]
+		delete(old, key.PakGUID)
+
+		event := base
+		event.DynamicKey = key
+
+		switch {
+		case !ok:
+			event.Type = AESDynamicKeyAdded
+			events = append(events, event)
+		case NormalizeAESKey(before.Key) != NormalizeAESKey(key.Key):
+			event.Type = AESDynamicKeyReplaced
+			event.Previous = before
+			events = append(events, event)
+		}
+
+		record, seen := w.history.Paks[key.PakGUID]
+		if !seen {
+			record = AESPakRecord{
+				PakGUID:     key.PakGUID,
+				PakFilename: key.PakFilename,
+				FirstBuild:  current.Build,
+				FirstSeen:   now,
+			}
+		}
+
+		record.Key = NormalizeAESKey(key.Key)
+		record.RemovedBuild = ""
+		w.history.Paks[key.PakGUID] = record
+	}
+
+	for _, key := range previous.DynamicKeys {
+		if _, ok := old[key.PakGUID]; !ok {
+			continue
+		}
+
+		event := base
+		event.Type = AESDynamicKeyRemoved
+		event.DynamicKey = key
+		events = append(events, event)
+
+		if record, ok := w.history.Paks[key.PakGUID]; ok {
+			record.RemovedBuild = current.Build
+			w.history.Paks[key.PakGUID] = record
+		}
+	}
+
+	w.previous = current
+	return events, nil
+}
+
+func (w *AESWatcher) Run(ctx context.Context, handler func(AESEvent)) error {
+	return runPolls(ctx, w.options.Clock, w.Poll, every(w.options.Interval), w.options.OnError, handler)
+}
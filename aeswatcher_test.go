@@ -0,0 +1,129 @@
+package fortniteapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NormalizeAESKey(t *testing.T) {
+	t.Parallel()
+
+	hexKey := "0x5D4BBA3C4F4E1C8B6B4F52F0A5E1D0F8C7E9A1B2C3D4E5F60718293A4B5C6D7E"
+	base64Key := "XUu6PE9OHItrT1LwpeHQ+MfpobLD1OX2BxgpOktcbX4="
+
+	assert.Equal(t, NormalizeAESKey(hexKey), NormalizeAESKey(base64Key))
+	assert.Equal(t, "5d4bba3c4f4e1c8b6b4f52f0a5e1d0f8c7e9a1b2c3d4e5f60718293a4b5c6d7e", NormalizeAESKey(hexKey))
+}
+
+func Test_AESWatcher_Poll(t *testing.T) {
+	t.Parallel()
+
+	server := &fakeServer[AESKeyResponse]{}
+	server.set(AESKeyResponse{
+		Build:   "++Fortnite+Release-33.00",
+		MainKey: "0xAA",
+		DynamicKeys: []AESDynamicKey{
+			{PakGUID: "g1", PakFilename: "pakchunk1.pak", Key: "0x01"},
+			{PakGUID: "g2", PakFilename: "pakchunk2.pak", Key: "0x02"},
+		},
+	})
+
+	client := newTestClient(t, server.handle(t))
+	watcher := NewAESWatcher(client, AESWatcherOptions{})
+
+	events, err := watcher.Poll(testCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, AESBuildChanged, events[0].Type)
+	assert.Equal(t, AESMainKeyChanged, events[1].Type)
+
+	events, err = watcher.Poll(testCtx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	server.set(AESKeyResponse{
+		Build:   "++Fortnite+Release-33.10",
+		MainKey: "0xaa",
+		DynamicKeys: []AESDynamicKey{
+			{PakGUID: "g2", PakFilename: "pakchunk2.pak", Key: "0x02"},
+			{PakGUID: "g3", PakFilename: "pakchunk3.pak", Key: "0x03"},
+		},
+	})
+
+	events, err = watcher.Poll(testCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, AESBuildChanged, events[0].Type)
+	assert.Equal(t, "++Fortnite+Release-33.00", events[0].PreviousBuild)
+	assert.Equal(t, AESDynamicKeyAdded, events[1].Type)
+	assert.Equal(t, "g3", events[1].DynamicKey.PakGUID)
+	assert.Equal(t, AESDynamicKeyRemoved, events[2].Type)
+	assert.Equal(t, "g1", events[2].DynamicKey.PakGUID)
+
+	history := watcher.History()
+	assert.Len(t, history.Builds, 2)
+
+	build, ok := history.FirstBuildForPak("g2")
+	assert.True(t, ok)
+	assert.Equal(t, "++Fortnite+Release-33.00", build)
+
+	build, ok = history.FirstBuildForPak("g3")
+	assert.True(t, ok)
+	assert.Equal(t, "++Fortnite+Release-33.10", build)
+
+	assert.Equal(t, "++Fortnite+Release-33.10", history.Paks["g1"].RemovedBuild)
+}
+
+func Test_AESWatcher_KeyFormat(t *testing.T) {
+	t.Parallel()
+
+	var formats []string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		formats = append(formats, r.URL.Query().Get("keyFormat"))
+		writeTestData(t, w, AESKeyResponse{})
+	})
+
+	_, err := NewAESWatcher(client, AESWatcherOptions{}).Poll(testCtx)
+	require.NoError(t, err)
+
+	_, err = NewAESWatcher(client, AESWatcherOptions{KeyFormat: "aes"}).Poll(testCtx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"hex", "aes"}, formats)
+}
+
+func Test_AESWatcher_HistoryDuringPoll(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		writeTestData(t, w, AESKeyResponse{Build: "++Fortnite+Release-33.10", MainKey: "0xBB"})
+	})
+
+	watcher := NewAESWatcher(client, AESWatcherOptions{})
+	watcher.Load(&AESKeyResponse{Build: "++Fortnite+Release-33.00"}, AESHistory{
+		Builds: []AESBuildRecord{{Build: "++Fortnite+Release-33.00"}},
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := watcher.Poll(testCtx)
+		done <- err
+	}()
+
+	// History returns while the request is still in flight.
+	<-started
+	assert.Len(t, watcher.History().Builds, 1)
+	close(release)
+
+	require.NoError(t, <-done)
+	assert.Len(t, watcher.History().Builds, 2)
+}
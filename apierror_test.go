@@ -0,0 +1,137 @@
+package fortniteapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_APIError_Is(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status int
+		target error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusBadGateway, ErrServerError},
+	}
+
+	for _, test := range tests {
+		err := fmt.Errorf("wrapped: %w", &APIError{Status: test.status})
+		assert.ErrorIs(t, err, test.target, "status %d", test.status)
+		assert.NotErrorIs(t, err, ErrEmptyParameter)
+	}
+
+	assert.NotErrorIs(t, &APIError{Status: http.StatusNotFound}, ErrForbidden)
+}
+
+func Test_Fetch_APIErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"status":404,"error":"the requested account does not exist"}`)
+	})
+
+	_, err := client.GetPlaylistByID(testCtx, testPlaylistID, nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	var apiError *APIError
+	require.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.MethodGet, apiError.Method)
+	assert.Equal(t, "/v1/playlists/"+testPlaylistID, apiError.Path)
+	assert.Equal(t, "abc", apiError.Header.Get("X-Request-Id"))
+	assert.Equal(t, "the requested account does not exist", apiError.Message)
+}
+
+func Test_Fetch_NonJSONErrorBody(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "<html><body>502 Bad Gateway</body></html>")
+	})
+
+	_, err := client.GetShop(testCtx, nil)
+	require.ErrorIs(t, err, ErrServerError)
+
+	var apiError *APIError
+	require.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusBadGateway, apiError.Status)
+	assert.Equal(t, "Bad Gateway", apiError.Message)
+	assert.Equal(t, "text/html", apiError.ContentType)
+	assert.Contains(t, apiError.Body, "502 Bad Gateway")
+	assert.Contains(t, err.Error(), "text/html")
+}
+
+func Test_Fetch_EmptyErrorBody(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.GetShop(testCtx, nil)
+
+	var apiError *APIError
+	require.ErrorAs(t, err, &apiError)
+	assert.Equal(t, http.StatusServiceUnavailable, apiError.Status)
+	assert.Empty(t, apiError.Body)
+}
+
+func Test_Fetch_TruncatesErrorBody(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, strings.Repeat("x", 4096))
+	})
+
+	_, err := client.GetShop(testCtx, nil)
+
+	var apiError *APIError
+	require.ErrorAs(t, err, &apiError)
+	assert.Len(t, apiError.Body, 512)
+}
+
+func Test_Fetch_EnvelopeStatusError(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":404,"error":"no cosmetic found"}`)
+	})
+
+	_, err := client.SearchBRCosmetic(testCtx, &SearchBRCosmeticParams{Name: testCosmeticName})
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Contains(t, err.Error(), "no cosmetic found")
+}
+
+func Test_Fetch_UndecodableSuccessBody(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html>maintenance</html>")
+	})
+
+	_, err := client.GetShop(testCtx, nil)
+
+	var decodeError *DecodeError
+	require.ErrorAs(t, err, &decodeError)
+	assert.Equal(t, http.StatusOK, decodeError.Status)
+	assert.Equal(t, "text/html", decodeError.ContentType)
+	assert.Equal(t, "<html>maintenance</html>", decodeError.Body)
+}
@@ -0,0 +1,443 @@
+package fortniteapi
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const assetIndexName = "index.json"
+
+var imageExtensions = []string{".png", ".jpg", ".jpeg", ".webp", ".gif"}
+
+// imageFieldSuffixes name the fields that only hold images, such as Icon,
+// SmallIcon, TileImage or AlbumArt, whose URLs may have no extension.
+var imageFieldSuffixes = []string{"Image", "Images", "Icon", "Art", "Featured"}
+
+// contentTypeExtensions names the stored files of the common image types.
+var contentTypeExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+	"image/gif":  ".gif",
+}
+
+// ErrNotImage is returned for URLs whose response isn't an image.
+var ErrNotImage = errors.New("response is not an image")
+
+// CollectImageURLs walks a response and returns the URLs in it that look
+// like images, by field path, such as "Data.BR[0].Images.Icon" or
+// `Entries[2].NewDisplayAsset.MaterialInstances[0].Images["Background"]`.
+// These are http and https URLs whose path ends in .png, .jpg, .jpeg, .webp
+// or .gif, and URLs without an extension in image fields such as Icon or
+// TileImage. The extension doesn't prove the URL serves an image;
+// AssetDownloader checks the Content-Type.
+func CollectImageURLs(response any) map[string]string {
+	urls := make(map[string]string)
+	collectImageURLs(reflect.ValueOf(response), "", "", urls)
+
+	return urls
+}
+
+// collectImageURLs adds the image URLs in v to urls. field is the name of
+// the struct field v is in, or in an element of.
+func collectImageURLs(v reflect.Value, fieldPath, field string, urls map[string]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectImageURLs(v.Elem(), fieldPath, field, urls)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			// Embedded fields are flattened, as encoding/json does.
+			if field.Anonymous {
+				collectImageURLs(v.Field(i), fieldPath, "", urls)
+				continue
+			}
+
+			collectImageURLs(v.Field(i), joinFieldPath(fieldPath, field.Name), field.Name, urls)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			collectImageURLs(v.Index(i), fieldPath+"["+strconv.Itoa(i)+"]", field, urls)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+
+		for _, key := range keys {
+			collectImageURLs(v.MapIndex(key), fieldPath+"["+strconv.Quote(fmt.Sprint(key.Interface()))+"]", field, urls)
+		}
+	case reflect.String:
+		if isImageURL(v.String(), field) {
+			urls[fieldPath] = v.String()
+		}
+	}
+}
+
+func joinFieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
+
+func isImageURL(value, field string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return false
+	}
+
+	if ext := strings.ToLower(path.Ext(parsed.Path)); ext != "" {
+		return slices.Contains(imageExtensions, ext)
+	}
+
+	return slices.ContainsFunc(imageFieldSuffixes, func(suffix string) bool {
+		return strings.HasSuffix(field, suffix)
+	})
+}
+
+// imageExtension returns the file extension for an image Content-Type.
+func imageExtension(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return "", false
+	}
+
+	if ext, ok := contentTypeExtensions[mediaType]; ok {
+		return ext, true
+	}
+
+	if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+		return exts[0], true
+	}
+
+	return "", true
+}
+
+type AssetDownloaderOptions struct {
+	// Default: 8
+	Concurrency int
+
+	// SaveEvery is how many downloaded files are added to the index before
+	// it is saved during a download. It is saved at the end either way.
+	//
+	// Default: 100
+	SaveEvery int
+}
+
+// AssetDownloader downloads the images of responses into a directory. Files
+// are named after the SHA-256 of their content, so an image behind several
+// URLs is stored once. An index of the downloaded URLs is kept in the
+// directory, and URLs already in it are not downloaded again.
+type AssetDownloader struct {
+	client      *Client
+	dir         string
+	concurrency int
+	saveEvery   int
+
+	mu    sync.Mutex
+	index map[string]assetIndexEntry
+
+	// unsaved counts the index entries added since the last save.
+	unsaved int
+
+	// saveMu keeps index writes in the order of their snapshots.
+	saveMu sync.Mutex
+}
+
+type assetIndexEntry struct {
+	File string `json:"file"`
+	Size int64  `json:"size"`
+}
+
+// Asset is a downloaded image. File is the path of the local copy.
+type Asset struct {
+	URL  string
+	File string
+	Size int64
+
+	// Cached is true when the image was already in the directory.
+	Cached bool
+
+	// Err is set when the image couldn't be downloaded.
+	Err error
+}
+
+// AssetManifest maps field paths, as returned by CollectImageURLs, to
+// assets.
+type AssetManifest struct {
+	Assets map[string]Asset
+}
+
+// Errors returns the assets that failed by field path.
+func (m *AssetManifest) Errors() map[string]error {
+	errs := make(map[string]error)
+	for fieldPath, asset := range m.Assets {
+		if asset.Err != nil {
+			errs[fieldPath] = asset.Err
+		}
+	}
+
+	return errs
+}
+
+// NewAssetDownloader creates the directory if needed and loads its index.
+// Images are downloaded with the client, so its HTTP client, retry policy
+// and rate limiter apply.
+func NewAssetDownloader(client *Client, dir string, options AssetDownloaderOptions) (*AssetDownloader, error) {
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+
+	if options.SaveEvery <= 0 {
+		options.SaveEvery = 100
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create asset directory: %w", err)
+	}
+
+	d := &AssetDownloader{
+		client:      client,
+		dir:         dir,
+		concurrency: options.Concurrency,
+		saveEvery:   options.SaveEvery,
+		index:       make(map[string]assetIndexEntry),
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, assetIndexName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read asset index: %w", err)
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(raw, &d.index); err != nil {
+			return nil, fmt.Errorf("failed to parse asset index: %w", err)
+		}
+	}
+
+	return d, nil
+}
+
+// Download downloads every image URL in the response. Failed images are
+// reported in the manifest, and URLs that turn out not to be images are
+// left out of it. The error is only set when the context is done or the
+// index can't be saved. The index is saved every SaveEvery files and at the
+// end, so an interrupted download skips the files it saved; a file that
+// was only partly downloaded is downloaded again from the start.
+func (d *AssetDownloader) Download(ctx context.Context, response any) (*AssetManifest, error) {
+	urls := CollectImageURLs(response)
+
+	var unique []string
+	for _, imageURL := range urls {
+		unique = append(unique, imageURL)
+	}
+
+	slices.Sort(unique)
+	unique = slices.Compact(unique)
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		assets  = make(map[string]Asset, len(unique))
+		jobs    = make(chan string)
+		saveErr error
+	)
+
+	for range d.concurrency {
+		wg.Go(func() {
+			for imageURL := range jobs {
+				asset := d.download(ctx, imageURL)
+
+				var err error
+				if d.saveDue() {
+					err = d.saveIndex()
+				}
+
+				mu.Lock()
+				assets[imageURL] = asset
+				saveErr = cmp.Or(saveErr, err)
+				mu.Unlock()
+			}
+		})
+	}
+
+	for _, imageURL := range unique {
+		if ctx.Err() != nil {
+			break
+		}
+
+		jobs <- imageURL
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	saveErr = cmp.Or(saveErr, d.saveIndex())
+
+	manifest := &AssetManifest{Assets: make(map[string]Asset, len(urls))}
+	for fieldPath, imageURL := range urls {
+		asset, ok := assets[imageURL]
+		if !ok {
+			asset = Asset{URL: imageURL, Err: ctx.Err()}
+		}
+
+		if !errors.Is(asset.Err, ErrNotImage) {
+			manifest.Assets[fieldPath] = asset
+		}
+	}
+
+	if saveErr != nil {
+		return manifest, saveErr
+	}
+
+	return manifest, ctx.Err()
+}
+
+func (d *AssetDownloader) download(ctx context.Context, imageURL string) Asset {
+	d.mu.Lock()
+	entry, ok := d.index[imageURL]
+	d.mu.Unlock()
+
+	if ok {
+		file := filepath.Join(d.dir, filepath.FromSlash(entry.File))
+		if info, err := os.Stat(file); err == nil && info.Size() == entry.Size {
+			return Asset{URL: imageURL, File: file, Size: entry.Size, Cached: true}
+		}
+	}
+
+	response, err := d.client.doImage(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return Asset{URL: imageURL, Err: err}
+	}
+
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		raw, err := io.ReadAll(response.Body)
+		if err != nil {
+			return Asset{URL: imageURL, Err: fmt.Errorf("failed to read image: %w", err)}
+		}
+
+		return Asset{URL: imageURL, Err: newAPIError(http.MethodGet, response.Request.URL.Path, response, raw)}
+	}
+
+	ext, ok := imageExtension(response.Header.Get("Content-Type"))
+	if !ok {
+		return Asset{URL: imageURL, Err: ErrNotImage}
+	}
+
+	name, size, err := d.store(response.Body, ext)
+	if err != nil {
+		return Asset{URL: imageURL, Err: err}
+	}
+
+	d.mu.Lock()
+	d.index[imageURL] = assetIndexEntry{File: filepath.ToSlash(name), Size: size}
+	d.unsaved++
+	d.mu.Unlock()
+
+	return Asset{URL: imageURL, File: filepath.Join(d.dir, name), Size: size}
+}
+
+// store streams body into a temporary file and renames it after its
+// SHA-256 once complete, so a partial download never takes the place of
+// an asset. It returns the name relative to the directory.
+func (d *AssetDownloader) store(body io.Reader, ext string) (string, int64, error) {
+	file, err := os.CreateTemp(d.dir, tempFilePrefix+"*")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to write asset: %w", err)
+	}
+
+	tempPath := file.Name()
+	defer os.Remove(tempPath) //nolint:errcheck
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(file, hash), body)
+	if err != nil {
+		file.Close() //nolint:errcheck
+		return "", 0, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close() //nolint:errcheck
+		return "", 0, fmt.Errorf("failed to write asset: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return "", 0, fmt.Errorf("failed to write asset: %w", err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	name := filepath.Join(sum[:2], sum+ext)
+
+	if err := os.MkdirAll(filepath.Join(d.dir, sum[:2]), 0o755); err != nil {
+		return "", 0, fmt.Errorf("failed to create asset directory: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filepath.Join(d.dir, name)); err != nil {
+		return "", 0, fmt.Errorf("failed to write asset: %w", err)
+	}
+
+	return name, size, nil
+}
+
+func (d *AssetDownloader) saveDue() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.unsaved >= d.saveEvery
+}
+
+// saveIndex writes the index if entries were added since the last save.
+func (d *AssetDownloader) saveIndex() error {
+	d.saveMu.Lock()
+	defer d.saveMu.Unlock()
+
+	d.mu.Lock()
+	if d.unsaved == 0 {
+		d.mu.Unlock()
+		return nil
+	}
+
+	raw, err := json.Marshal(d.index)
+	d.unsaved = 0
+	d.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to encode asset index: %w", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(d.dir, assetIndexName), raw); err != nil {
+		return fmt.Errorf("failed to write asset index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package fortniteapi
+
+import (
+	"context"
+	"encoding/json"
+	"image/color"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CollectImageURLs(t *testing.T) {
+	t.Parallel()
+
+	response := &AllCosmeticsResponse{
+		BR: []BRCosmetic{{
+			ID:     "CID_1",
+			Images: BRCosmeticImages{Icon: "https://example.com/icon.png", SmallIcon: "not a url"},
+		}},
+		Tracks: []Track{{AlbumArt: "https://example.com/art.JPG"}},
+	}
+
+	assert.Equal(t, map[string]string{
+		"BR[0].Images.Icon":  "https://example.com/icon.png",
+		"Tracks[0].AlbumArt": "https://example.com/art.JPG",
+	}, CollectImageURLs(response))
+
+	news := NewsMotd{Image: "https://example.com/motd.jpeg", TileImage: "https://example.com/tile", WebsiteURL: "https://example.com/news"}
+	assert.Equal(t, map[string]string{
+		"Image":     "https://example.com/motd.jpeg",
+		"TileImage": "https://example.com/tile",
+	}, CollectImageURLs(news))
+
+	instance := ShopItemNewDisplayAssetMaterialInstance{Images: map[string]string{
+		"Background": "https://example.com/bg.png",
+		"OfferImage": "https://example.com/offer",
+	}}
+	assert.Equal(t, map[string]string{
+		`Images["Background"]`: "https://example.com/bg.png",
+		`Images["OfferImage"]`: "https://example.com/offer",
+	}, CollectImageURLs(instance))
+}
+
+func Test_AssetDownloader_Download(t *testing.T) {
+	t.Parallel()
+
+	red := testPNG(t, color.RGBA{R: 0xff, A: 0xff})
+	blue := testPNG(t, color.RGBA{B: 0xff, A: 0xff})
+
+	var requests atomic.Int32
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		switch r.URL.Path {
+		case "/red.png", "/copy.png":
+			w.Write(red) //nolint:errcheck
+		case "/blue":
+			w.Write(blue) //nolint:errcheck
+		case "/page.png":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>")) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	response := &AllCosmeticsResponse{
+		BR: []BRCosmetic{
+			{Images: BRCosmeticImages{Icon: client.baseURL + "/red.png", Featured: client.baseURL + "/copy.png"}},
+			{Images: BRCosmeticImages{Icon: client.baseURL + "/red.png", SmallIcon: client.baseURL + "/missing.png"}},
+		},
+		Tracks: []Track{{AlbumArt: client.baseURL + "/blue"}, {AlbumArt: client.baseURL + "/page.png"}},
+	}
+
+	dir := t.TempDir()
+
+	downloader, err := NewAssetDownloader(client, dir, AssetDownloaderOptions{Concurrency: 2})
+	require.NoError(t, err)
+
+	manifest, err := downloader.Download(testCtx, response)
+	require.NoError(t, err)
+
+	require.Len(t, manifest.Assets, 5)
+	assert.NotContains(t, manifest.Assets, "Tracks[1].AlbumArt", "pages are not images")
+	assert.Equal(t, int32(5), requests.Load(), "each URL is downloaded once")
+
+	icon := manifest.Assets["BR[0].Images.Icon"]
+	require.NoError(t, icon.Err)
+	assert.Equal(t, icon, manifest.Assets["BR[1].Images.Icon"])
+	assert.Equal(t, icon.File, manifest.Assets["BR[0].Images.Featured"].File, "same content is stored once")
+	assert.NotEqual(t, icon.File, manifest.Assets["Tracks[0].AlbumArt"].File)
+	assert.Equal(t, ".png", filepath.Ext(manifest.Assets["Tracks[0].AlbumArt"].File), "named after the Content-Type")
+
+	data, err := os.ReadFile(icon.File)
+	require.NoError(t, err)
+	assert.Equal(t, red, data)
+
+	errs := manifest.Errors()
+	require.Len(t, errs, 1)
+
+	var apiErr *APIError
+	require.ErrorAs(t, errs["BR[1].Images.SmallIcon"], &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.Status)
+
+	// A new downloader resumes from the index and only retries what it did not store.
+	downloader, err = NewAssetDownloader(client, dir, AssetDownloaderOptions{})
+	require.NoError(t, err)
+
+	manifest, err = downloader.Download(testCtx, response)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(7), requests.Load())
+	assert.True(t, manifest.Assets["Tracks[0].AlbumArt"].Cached)
+	assert.Equal(t, icon.File, manifest.Assets["BR[0].Images.Icon"].File)
+}
+
+func Test_AssetDownloader_SavesIndexInBatches(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	png := testPNG(t, color.RGBA{R: 0xff, A: 0xff})
+
+	ctx, cancel := context.WithCancel(testCtx)
+	defer cancel()
+
+	indexed := make(map[string]int)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var index map[string]assetIndexEntry
+		if raw, err := os.ReadFile(filepath.Join(dir, assetIndexName)); err == nil {
+			require.NoError(t, json.Unmarshal(raw, &index))
+		}
+
+		indexed[r.URL.Path] = len(index)
+
+		if r.URL.Path == "/c.png" {
+			// The process dies while the third image downloads.
+			cancel()
+			return
+		}
+
+		w.Write(png) //nolint:errcheck
+	})
+
+	downloader, err := NewAssetDownloader(client, dir, AssetDownloaderOptions{Concurrency: 1, SaveEvery: 2})
+	require.NoError(t, err)
+
+	_, err = downloader.Download(ctx, &AllCosmeticsResponse{
+		BR: []BRCosmetic{{Images: BRCosmeticImages{
+			Icon:      client.baseURL + "/a.png",
+			SmallIcon: client.baseURL + "/b.png",
+			Featured:  client.baseURL + "/c.png",
+		}}},
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, map[string]int{"/a.png": 0, "/b.png": 0, "/c.png": 2}, indexed)
+
+	matches, err := filepath.Glob(filepath.Join(dir, tempFilePrefix+"*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
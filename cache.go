@@ -0,0 +1,280 @@
+package fortniteapi
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrCacheMiss = errors.New("cache miss")
+
+type CacheEntry struct {
+	// Body is the raw response envelope as returned by the API.
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+func (e *CacheEntry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// Cache stores raw responses for Client.Fetch. Get must return ErrCacheMiss
+// when the key is unknown. Expired entries should still be returned, the
+// client uses them to revalidate with the server.
+type Cache interface {
+	Get(ctx context.Context, key string) (*CacheEntry, error)
+	Set(ctx context.Context, key string, entry *CacheEntry) error
+	Delete(ctx context.Context, key string) error
+}
+
+type CacheOptions struct {
+	// DefaultTTL applies to every GET request without a more specific TTL.
+	// Zero disables caching for those requests.
+	DefaultTTL time.Duration
+
+	// TTLs maps a path prefix such as "/v2/cosmetics" to its TTL. The
+	// longest matching prefix wins.
+	TTLs map[string]time.Duration
+
+	// ServeStaleOnError returns an expired entry when the server can't be
+	// reached or answers with a 429 or 5xx status, which keeps the client
+	// usable offline with the last known good responses.
+	ServeStaleOnError bool
+
+	// OnWriteError is called when a response can't be stored. The
+	// response is still returned.
+	OnWriteError func(key string, err error)
+}
+
+func WithCache(cache Cache, options CacheOptions) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheOptions = options
+	}
+}
+
+// Invalidate removes the cached response of a GET request, built from the
+// same path and query that would be passed to Get.
+func (c *Client) Invalidate(ctx context.Context, path string, query any) error {
+	if c.cache == nil {
+		return nil
+	}
+
+	fullURL, err := c.buildURL(path, query)
+	if err != nil {
+		return err
+	}
+
+	return c.cache.Delete(ctx, cacheKey(http.MethodGet, fullURL))
+}
+
+func (c *Client) cacheTTL(path string) time.Duration {
+	ttl := c.cacheOptions.DefaultTTL
+	matched := -1
+
+	for prefix, prefixTTL := range c.cacheOptions.TTLs {
+		if strings.HasPrefix(path, prefix) && len(prefix) > matched {
+			ttl = prefixTTL
+			matched = len(prefix)
+		}
+	}
+
+	return ttl
+}
+
+func (c *Client) fetchCached(ctx context.Context, path, fullURL string, ttl time.Duration, out any) error {
+	key := cacheKey(http.MethodGet, fullURL)
+
+	entry, err := c.cache.Get(ctx, key)
+	if err != nil {
+		entry = nil
+	}
+
+	if entry != nil && entry.Fresh(time.Now()) {
+		return decodeCached(entry.Body, out)
+	}
+
+	header := http.Header{}
+	if entry != nil {
+		if entry.ETag != "" {
+			header.Set("If-None-Match", entry.ETag)
+		}
+
+		if entry.LastModified != "" {
+			header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	staleAllowed := entry != nil && c.cacheOptions.ServeStaleOnError
+
+	response, err := c.do(ctx, http.MethodGet, fullURL, nil, header)
+	if err != nil {
+		if staleAllowed && ctx.Err() == nil {
+			return decodeCached(entry.Body, out)
+		}
+
+		return err
+	}
+
+	defer response.Body.Close() //nolint:errcheck
+
+	if staleAllowed && (response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError) {
+		return decodeCached(entry.Body, out)
+	}
+
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	now := time.Now()
+
+	if response.StatusCode == http.StatusNotModified && entry != nil {
+		revalidated := *entry
+		revalidated.StoredAt = now
+		revalidated.ExpiresAt = now.Add(ttl)
+
+		if err := decodeCached(entry.Body, out); err != nil {
+			return err
+		}
+
+		c.storeCache(ctx, key, &revalidated)
+
+		return nil
+	}
+
+	if err := decodeResponse(http.MethodGet, path, response, raw, out); err != nil {
+		return err
+	}
+
+	c.storeCache(ctx, key, &CacheEntry{
+		Body:         raw,
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+		StoredAt:     now,
+		ExpiresAt:    now.Add(ttl),
+	})
+
+	return nil
+}
+
+// storeCache stores an entry. Failures are reported to OnWriteError and
+// don't fail the request, since the response is already decoded.
+func (c *Client) storeCache(ctx context.Context, key string, entry *CacheEntry) {
+	err := c.cache.Set(ctx, key, entry)
+	if err != nil && c.cacheOptions.OnWriteError != nil {
+		c.cacheOptions.OnWriteError(key, fmt.Errorf("failed to write cache: %w", err))
+	}
+}
+
+func decodeCached(raw []byte, out any) error {
+	var envelope apiEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("failed to decode cached response: %w", err)
+	}
+
+	return unmarshalData(envelope.Data, out)
+}
+
+func cacheKey(method, fullURL string) string {
+	return method + " " + fullURL
+}
+
+const defaultMemoryCacheEntries = 1000
+
+type MemoryCacheOptions struct {
+	// MaxEntries is the maximum number of entries kept in memory. The least
+	// recently used entries are evicted first.
+	//
+	// Default: 1000
+	MaxEntries int
+}
+
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+func NewMemoryCache(options MemoryCacheOptions) *MemoryCache {
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = defaultMemoryCacheEntries
+	}
+
+	return &MemoryCache{
+		maxEntries: options.MaxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *MemoryCache) Get(_ context.Context, key string) (*CacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryCacheItem).entry, nil
+}
+
+func (m *MemoryCache) Set(_ context.Context, key string, entry *CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryCacheItem).entry = entry
+		m.order.MoveToFront(element)
+
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+
+	for m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+
+	return nil
+}
+
+func (m *MemoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.order.Remove(element)
+		delete(m.entries, key)
+	}
+
+	return nil
+}
+
+func (m *MemoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.entries)
+	m.order.Init()
+}
@@ -0,0 +1,186 @@
+package fortniteapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Cache_ServesFreshEntries(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		writeTestData(t, w, BRMapResponse{Images: BRMapImages{Blank: "blank.png"}})
+	}, WithCache(NewMemoryCache(MemoryCacheOptions{}), CacheOptions{DefaultTTL: time.Hour}))
+
+	for range 3 {
+		resp, err := client.GetBRMap(testCtx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "blank.png", resp.Images.Blank)
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_Cache_KeyedByLanguage(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		writeTestData(t, w, nil)
+	}, WithCache(NewMemoryCache(MemoryCacheOptions{}), CacheOptions{DefaultTTL: time.Hour}))
+
+	_, err := client.GetBanners(testCtx, nil)
+	require.NoError(t, err)
+
+	_, err = client.GetBanners(testCtx, &BannersParams{Language: LanguageGerman})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_Cache_PerPathTTL(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		writeTestData(t, w, nil)
+	}, WithCache(NewMemoryCache(MemoryCacheOptions{}), CacheOptions{
+		TTLs: map[string]time.Duration{"/v1/playlists": time.Hour},
+	}))
+
+	for range 2 {
+		_, err := client.GetPlaylists(testCtx, nil)
+		require.NoError(t, err)
+
+		_, err = client.GetShop(testCtx, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func Test_Cache_RevalidatesWithETag(t *testing.T) {
+	t.Parallel()
+
+	var calls, notModified atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		writeTestData(t, w, AESKeyResponse{Build: "v1"})
+	}, WithCache(NewMemoryCache(MemoryCacheOptions{}), CacheOptions{DefaultTTL: time.Nanosecond}))
+
+	for range 2 {
+		resp, err := client.GetAESKey(testCtx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", resp.Build)
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+}
+
+func Test_Cache_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		writeTestData(t, w, nil)
+	}, WithCache(NewMemoryCache(MemoryCacheOptions{}), CacheOptions{DefaultTTL: time.Hour}))
+
+	_, err := client.GetBRMap(testCtx, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Invalidate(testCtx, "/v1/map", nil))
+
+	_, err = client.GetBRMap(testCtx, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_Cache_SkipsErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithCache(NewMemoryCache(MemoryCacheOptions{}), CacheOptions{DefaultTTL: time.Hour}))
+
+	for range 2 {
+		_, err := client.GetBRMap(testCtx, nil)
+		require.ErrorIs(t, err, ErrServerError)
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+type failingCache struct {
+	*MemoryCache
+}
+
+func (failingCache) Set(context.Context, string, *CacheEntry) error {
+	return errors.New("disk full")
+}
+
+func Test_Cache_WriteErrorsAreNotFatal(t *testing.T) {
+	t.Parallel()
+
+	var writeErrors atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeTestData(t, w, BRMapResponse{Images: BRMapImages{Blank: "blank.png"}})
+	}, WithCache(failingCache{NewMemoryCache(MemoryCacheOptions{})}, CacheOptions{
+		DefaultTTL: time.Hour,
+		OnWriteError: func(_ string, err error) {
+			assert.ErrorContains(t, err, "disk full")
+			writeErrors.Add(1)
+		},
+	}))
+
+	resp, err := client.GetBRMap(testCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "blank.png", resp.Images.Blank)
+	assert.Equal(t, int32(1), writeErrors.Load())
+}
+
+func Test_MemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache := NewMemoryCache(MemoryCacheOptions{MaxEntries: 2})
+
+	require.NoError(t, cache.Set(testCtx, "a", &CacheEntry{Body: []byte("a")}))
+	require.NoError(t, cache.Set(testCtx, "b", &CacheEntry{Body: []byte("b")}))
+
+	_, err := cache.Get(testCtx, "a")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set(testCtx, "c", &CacheEntry{Body: []byte("c")}))
+
+	_, err = cache.Get(testCtx, "b")
+	require.ErrorIs(t, err, ErrCacheMiss)
+
+	for _, key := range []string{"a", "c"} {
+		entry, err := cache.Get(testCtx, key)
+		require.NoError(t, err)
+		assert.Equal(t, key, string(entry.Body))
+	}
+}
@@ -0,0 +1,342 @@
+package fortniteapi
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"slices"
+	"strings"
+)
+
+// DefaultRarityColors maps lowercase rarity values to the top and bottom
+// colors of the card background.
+var DefaultRarityColors = map[string][2]color.NRGBA{
+	"common":    {{R: 0xbe, G: 0xbe, B: 0xbe, A: 0xff}, {R: 0x64, G: 0x64, B: 0x64, A: 0xff}},
+	"uncommon":  {{R: 0x69, G: 0xbb, B: 0x1e, A: 0xff}, {R: 0x17, G: 0x51, B: 0x17, A: 0xff}},
+	"rare":      {{R: 0x2c, G: 0xc1, B: 0xff, A: 0xff}, {R: 0x14, G: 0x39, B: 0x77, A: 0xff}},
+	"epic":      {{R: 0xc3, G: 0x59, B: 0xff, A: 0xff}, {R: 0x4b, G: 0x24, B: 0x83, A: 0xff}},
+	"legendary": {{R: 0xea, G: 0x8d, B: 0x23, A: 0xff}, {R: 0x78, G: 0x37, B: 0x1d, A: 0xff}},
+	"mythic":    {{R: 0xff, G: 0xd8, B: 0x2e, A: 0xff}, {R: 0x8a, G: 0x62, B: 0x0c, A: 0xff}},
+	"exotic":    {{R: 0x76, G: 0xd6, B: 0xe3, A: 0xff}, {R: 0x1f, G: 0x6c, B: 0x78, A: 0xff}},
+}
+
+// CardTemplate holds the geometry and colors of a cosmetic card. Sizes are
+// in pixels.
+type CardTemplate struct {
+	// Width is also the height of the icon area.
+	//
+	// Default: 512
+	Width int
+
+	// Default: 16
+	Padding int
+
+	// ThumbnailSize is the size of variant and LEGO or Bean thumbnails.
+	//
+	// Default: 72
+	ThumbnailSize int
+
+	// Default: 32
+	TitleTextSize float64
+
+	// Default: 18
+	TextSize float64
+
+	// Default: white
+	TextColor color.Color
+
+	// Default: DefaultRarityColors
+	RarityColors map[string][2]color.NRGBA
+}
+
+func (t *CardTemplate) setDefaults() {
+	if t.Width <= 0 {
+		t.Width = 512
+	}
+
+	if t.Padding <= 0 {
+		t.Padding = 16
+	}
+
+	if t.ThumbnailSize <= 0 {
+		t.ThumbnailSize = 72
+	}
+
+	if t.TitleTextSize <= 0 {
+		t.TitleTextSize = 32
+	}
+
+	if t.TextSize <= 0 {
+		t.TextSize = 18
+	}
+
+	if t.TextColor == nil {
+		t.TextColor = color.White
+	}
+
+	if t.RarityColors == nil {
+		t.RarityColors = DefaultRarityColors
+	}
+}
+
+type CardRendererOptions struct {
+	// Fetcher downloads the images. A *Client works.
+	Fetcher ImageFetcher
+
+	// Text draws the name, description, set and introduction. Render fails
+	// with ErrNoTextDrawer without it.
+	Text TextDrawer
+
+	Template CardTemplate
+
+	// Default: 8
+	Concurrency int
+
+	// OnError is called for images that fail to download or decode. The
+	// card is drawn without them.
+	OnError func(url string, err error)
+}
+
+// CardRenderer draws a single cosmetic: its icon over the series art or a
+// rarity colored background, variant thumbnails, LEGO and Bean alternates,
+// and its texts.
+type CardRenderer struct {
+	options CardRendererOptions
+}
+
+func NewCardRenderer(options CardRendererOptions) *CardRenderer {
+	options.Template.setDefaults()
+
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+
+	return &CardRenderer{options: options}
+}
+
+// cardDetails holds what the Cosmetic interface doesn't expose.
+type cardDetails struct {
+	description  string
+	set          string
+	introduction string
+	seriesImage  string
+	seriesColors []string
+	variants     []string
+	alternates   []string
+}
+
+func newCardDetails(cosmetic Cosmetic) cardDetails {
+	var details cardDetails
+
+	switch c := cosmetic.(type) {
+	case *BRCosmetic:
+		details.description = c.Description
+		details.set = c.Set.Text
+		details.introduction = c.Introduction.Text
+		details.seriesImage = c.Series.Image
+		details.seriesColors = c.Series.Colors
+
+		for _, variant := range c.Variants {
+			for _, option := range variant.Options {
+				if option.Image != "" {
+					details.variants = append(details.variants, option.Image)
+				}
+			}
+		}
+
+		for _, alternate := range []string{
+			firstNonEmpty(c.Images.Lego.Large, c.Images.Lego.Small, c.Images.Lego.Wide),
+			firstNonEmpty(c.Images.Bean.Large, c.Images.Bean.Small),
+		} {
+			if alternate != "" {
+				details.alternates = append(details.alternates, alternate)
+			}
+		}
+	case *Track:
+		details.description = strings.Join(slices.DeleteFunc([]string{c.Artist, c.Album}, func(s string) bool { return s == "" }), " - ")
+	case *Instrument:
+		details.description = c.Description
+		details.seriesImage = c.Series.Image
+		details.seriesColors = c.Series.Colors
+	case *Car:
+		details.description = c.Description
+		details.seriesImage = c.Series.Image
+		details.seriesColors = c.Series.Colors
+	case *LegoKit:
+		details.seriesImage = c.Series.Image
+		details.seriesColors = c.Series.Colors
+	}
+
+	return details
+}
+
+// RenderPNG renders the card and writes it as PNG.
+func (r *CardRenderer) RenderPNG(ctx context.Context, cosmetic Cosmetic, w io.Writer) error {
+	img, err := r.Render(ctx, cosmetic)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to encode card image: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CardRenderer) Render(ctx context.Context, cosmetic Cosmetic) (*image.RGBA, error) {
+	if r.options.Text == nil {
+		return nil, ErrNoTextDrawer
+	}
+
+	template := &r.options.Template
+	details := newCardDetails(cosmetic)
+
+	width := template.Width
+	height := width + template.Padding
+
+	// Variants that don't fit in one row are left out, and not fetched.
+	perRow := max((width-template.Padding)/(template.ThumbnailSize+template.Padding), 1)
+	variants := details.variants[:min(len(details.variants), perRow)]
+
+	urls := append([]string{cosmetic.BestImageURL(), details.seriesImage}, details.alternates...)
+	urls = append(urls, variants...)
+
+	images, err := fetchImages(ctx, r.options.Fetcher, urls, r.options.Concurrency, r.options.OnError)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := []struct {
+		text string
+		size float64
+	}{
+		{cosmetic.DisplayName(), template.TitleTextSize},
+		{details.description, template.TextSize},
+		{details.set, template.TextSize},
+		{details.introduction, template.TextSize},
+	}
+
+	if len(variants) > 0 {
+		height += template.ThumbnailSize + template.Padding
+	}
+
+	for _, text := range texts {
+		if text.text != "" {
+			height += int(text.size * 1.5)
+		}
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	r.drawBackground(canvas, canvas.Bounds(), cosmetic, details, images[details.seriesImage])
+
+	iconArea := image.Rect(0, 0, width, width)
+	if icon := images[cosmetic.BestImageURL()]; icon != nil {
+		drawContain(canvas, iconArea.Inset(template.Padding), icon)
+	}
+
+	// LEGO and Bean alternates are stacked in the top right corner.
+	for i, alternate := range details.alternates {
+		img := images[alternate]
+		if img == nil {
+			continue
+		}
+
+		x := width - template.Padding - template.ThumbnailSize
+		y := template.Padding + i*(template.ThumbnailSize+template.Padding)
+		r.drawThumbnail(canvas, image.Rect(x, y, x+template.ThumbnailSize, y+template.ThumbnailSize), img)
+	}
+
+	y := width
+
+	if len(variants) > 0 {
+		for i, variant := range variants {
+			x := template.Padding + i*(template.ThumbnailSize+template.Padding)
+			if img := images[variant]; img != nil {
+				r.drawThumbnail(canvas, image.Rect(x, y, x+template.ThumbnailSize, y+template.ThumbnailSize), img)
+			}
+		}
+
+		y += template.ThumbnailSize + template.Padding
+	}
+
+	for i, text := range texts {
+		if text.text == "" {
+			continue
+		}
+
+		lineHeight := int(text.size * 1.5)
+		rect := image.Rect(template.Padding, y, width-template.Padding, y+lineHeight)
+
+		r.options.Text.DrawText(canvas, text.text, rect, TextStyle{
+			Size:  text.size,
+			Color: template.TextColor,
+			Bold:  i == 0,
+			Align: TextAlignCenter,
+		})
+
+		y += lineHeight
+	}
+
+	return canvas, nil
+}
+
+// drawBackground uses the series art, then the series colors, then the
+// rarity colors, then the common colors.
+func (r *CardRenderer) drawBackground(canvas *image.RGBA, rect image.Rectangle, cosmetic Cosmetic, details cardDetails, seriesImage image.Image) {
+	template := &r.options.Template
+
+	colors, ok := template.RarityColors[strings.ToLower(cosmetic.RarityValue())]
+	if !ok {
+		colors = template.RarityColors["common"]
+	}
+
+	if len(details.seriesColors) > 0 {
+		if top, ok := parseHexColor(details.seriesColors[0]); ok {
+			bottom := top
+			if len(details.seriesColors) > 1 {
+				if parsed, ok := parseHexColor(details.seriesColors[1]); ok {
+					bottom = parsed
+				}
+			}
+
+			colors = [2]color.NRGBA{top, bottom}
+		}
+	}
+
+	drawGradient(canvas, rect, colors[0], colors[1])
+
+	if seriesImage != nil {
+		drawCover(canvas, image.Rect(0, 0, template.Width, template.Width), seriesImage)
+	}
+
+	// Darken the text area so light backgrounds stay readable.
+	textArea := image.Rect(rect.Min.X, template.Width, rect.Max.X, rect.Max.Y)
+	draw.Draw(canvas, textArea, image.NewUniform(color.NRGBA{A: 0x80}), image.Point{}, draw.Over)
+}
+
+func (r *CardRenderer) drawThumbnail(canvas *image.RGBA, rect image.Rectangle, img image.Image) {
+	draw.Draw(canvas, rect, image.NewUniform(color.NRGBA{A: 0x60}), image.Point{}, draw.Over)
+	drawContain(canvas, rect.Inset(4), img)
+}
+
+// drawCover scales src to cover rect, keeping its aspect ratio and
+// cropping what overflows.
+func drawCover(dst draw.Image, rect image.Rectangle, src image.Image) {
+	bounds := src.Bounds()
+	if bounds.Empty() || rect.Empty() {
+		return
+	}
+
+	scale := max(float64(rect.Dx())/float64(bounds.Dx()), float64(rect.Dy())/float64(bounds.Dy()))
+	size := image.Pt(max(int(float64(bounds.Dx())*scale), rect.Dx()), max(int(float64(bounds.Dy())*scale), rect.Dy()))
+
+	scaled := scaleNearest(src, size)
+	offset := image.Pt((size.X-rect.Dx())/2, (size.Y-rect.Dy())/2)
+
+	draw.Draw(dst, rect, scaled, offset, draw.Over)
+}
@@ -0,0 +1,141 @@
+package fortniteapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var noText = TextDrawerFunc(func(draw.Image, string, image.Rectangle, TextStyle) {})
+
+func Test_CardRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string][]byte{
+		"icon.png":    testPNG(t, color.RGBA{R: 0xff, A: 0xff}),
+		"variant.png": testPNG(t, color.RGBA{G: 0xff, A: 0xff}),
+		"lego.png":    testPNG(t, color.RGBA{B: 0xff, A: 0xff}),
+		"series.png":  testPNG(t, color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}),
+	}
+
+	fetcher := ImageFetcherFunc(func(_ context.Context, url string) ([]byte, error) {
+		if data, ok := fixtures[url]; ok {
+			return data, nil
+		}
+
+		return nil, errors.New("not found")
+	})
+
+	var texts []string
+
+	renderer := NewCardRenderer(CardRendererOptions{
+		Fetcher: fetcher,
+		Text: TextDrawerFunc(func(_ draw.Image, text string, _ image.Rectangle, _ TextStyle) {
+			texts = append(texts, text)
+		}),
+		Template: CardTemplate{Width: 200, Padding: 10, ThumbnailSize: 40, TitleTextSize: 20, TextSize: 10},
+	})
+
+	cosmetic := &BRCosmetic{
+		Name:         "Renegade Raider",
+		Description:  "Rare renegade raider outfit.",
+		Rarity:       BRCosmeticRarity{Value: "epic"},
+		Introduction: BRCosmeticIntroduction{Text: "Introduced in Chapter 1, Season 1."},
+		Images:       BRCosmeticImages{Icon: "icon.png", Lego: BRCosmeticLegoImages{Small: "lego.png"}},
+		Variants: []BRCosmeticItemVariant{{Options: []BRCosmeticVariantOption{
+			{Image: "variant.png"},
+			{Image: "variant.png"},
+		}}},
+	}
+
+	img, err := renderer.Render(testCtx, cosmetic)
+	require.NoError(t, err)
+
+	// 200 icon area, 10 padding, 40 + 10 variants, 30 + 15 + 15 text.
+	assert.Equal(t, image.Rect(0, 0, 200, 320), img.Bounds())
+	assert.Equal(t, color.RGBA{R: 0xc3, G: 0x59, B: 0xff, A: 0xff}, img.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{R: 0xff, A: 0xff}, img.RGBAAt(100, 100))
+	assert.Equal(t, color.RGBA{B: 0xff, A: 0xff}, img.RGBAAt(170, 30))
+	assert.Equal(t, color.RGBA{G: 0xff, A: 0xff}, img.RGBAAt(30, 230))
+	assert.Equal(t, color.RGBA{G: 0xff, A: 0xff}, img.RGBAAt(80, 230))
+	assert.Equal(t, []string{"Renegade Raider", "Rare renegade raider outfit.", "Introduced in Chapter 1, Season 1."}, texts)
+
+	cosmetic.Series = BRCosmeticSeries{Image: "series.png", Colors: []string{"ff0000ff", "00ff00ff"}}
+	cosmetic.Images.Icon = ""
+
+	var buf bytes.Buffer
+	require.NoError(t, renderer.RenderPNG(testCtx, cosmetic, &buf))
+
+	decoded, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	r, g, b, _ := decoded.At(100, 100).RGBA()
+	assert.Equal(t, [3]uint32{0x12, 0x34, 0x56}, [3]uint32{r >> 8, g >> 8, b >> 8})
+}
+
+func Test_CardRenderer_OtherCosmetics(t *testing.T) {
+	t.Parallel()
+
+	renderer := NewCardRenderer(CardRendererOptions{Text: noText, Template: CardTemplate{Width: 100}})
+
+	img, err := renderer.Render(testCtx, &Car{Name: "Octane", Series: BRCosmeticSeries{Colors: []string{"00ff00"}}})
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{G: 0xff, A: 0xff}, img.RGBAAt(0, 0))
+
+	img, err = renderer.Render(testCtx, &Bean{Name: "Bean"})
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 0xbe, G: 0xbe, B: 0xbe, A: 0xff}, img.RGBAAt(0, 0))
+}
+
+func Test_CardRenderer_FetchesDrawnVariants(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		fetched []string
+	)
+
+	fetcher := ImageFetcherFunc(func(_ context.Context, url string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fetched = append(fetched, url)
+		return nil, errors.New("not found")
+	})
+
+	renderer := NewCardRenderer(CardRendererOptions{
+		Fetcher:  fetcher,
+		Text:     noText,
+		Template: CardTemplate{Width: 200, Padding: 10, ThumbnailSize: 40},
+	})
+
+	var options []BRCosmeticVariantOption
+	for _, image := range []string{"v1.png", "v2.png", "v3.png", "v4.png", "v5.png"} {
+		options = append(options, BRCosmeticVariantOption{Image: image})
+	}
+
+	_, err := renderer.Render(testCtx, &BRCosmetic{
+		Images:   BRCosmeticImages{Icon: "icon.png"},
+		Variants: []BRCosmeticItemVariant{{Options: options}},
+	})
+	require.NoError(t, err)
+
+	// Three thumbnails fit in a row of 200.
+	assert.ElementsMatch(t, []string{"icon.png", "v1.png", "v2.png", "v3.png"}, fetched)
+}
+
+func Test_CardRenderer_RequiresTextDrawer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCardRenderer(CardRendererOptions{}).Render(testCtx, &Bean{Name: "Bean"})
+	require.ErrorIs(t, err, ErrNoTextDrawer)
+}
@@ -7,20 +7,33 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	querypkg "github.com/google/go-querystring/query"
 )
 
 const (
 	version = "v1.0.1"
-	baseURL = "https://fortnite-api.com"
+
+	defaultBaseURL   = "https://fortnite-api.com"
+	defaultUserAgent = "go-fortnite-api/" + version
 )
 
 var (
 	ErrNoAPIKey       = errors.New("an API key is required for this request")
 	ErrEmptyParameter = errors.New("parameter cannot be empty")
+
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServerError  = errors.New("server error")
 )
 
 type APIResponse[T any] struct {
@@ -28,27 +41,109 @@ type APIResponse[T any] struct {
 	Data   T   `json:"data"`
 }
 
+type apiEnvelope struct {
+	Status int             `json:"status"`
+	Error  string          `json:"error"`
+	Data   json.RawMessage `json:"data"`
+}
+
 type APIError struct {
-	Status  int    `json:"status"`
-	Message string `json:"error"`
+	Status      int         `json:"status"`
+	Message     string      `json:"error"`
+	Method      string      `json:"-"`
+	Path        string      `json:"-"`
+	Header      http.Header `json:"-"`
+	ContentType string      `json:"-"`
+
+	// Body holds the start of the raw response body, truncated to 512 bytes.
+	Body string `json:"-"`
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("api error: %d - %s", e.Status, e.Message)
+	message := fmt.Sprintf("api error: %d - %s", e.Status, e.Message)
+	if e.Method != "" {
+		message = fmt.Sprintf("api error: %s %s: %d - %s", e.Method, e.Path, e.Status, e.Message)
+	}
+
+	if e.ContentType != "" && !isJSONContentType(e.ContentType) {
+		message += " (" + e.ContentType + ")"
+	}
+
+	return message
+}
+
+// Is reports whether the error matches one of the status sentinel errors,
+// so callers can use errors.Is(err, ErrNotFound) instead of comparing codes.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.Status == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.Status >= http.StatusInternalServerError
+	default:
+		return false
+	}
 }
 
-type Client struct {
-	language   Language
-	httpClient *http.Client
-	apiKey     string
+// DecodeError is returned when a successful response can't be decoded,
+// for example when a proxy answers with an HTML page.
+type DecodeError struct {
+	Status      int
+	ContentType string
+	Body        string
+	Err         error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode response: %d (%s): %v", e.Status, e.ContentType, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
 }
 
-func NewClient(language Language, apiKey string) *Client {
-	return &Client{
+type Client struct {
+	language      Language
+	httpClient    *http.Client
+	apiKey        string
+	baseURL       string
+	userAgent     string
+	timeout       time.Duration
+	responseFlags ResponseFlag
+	retryPolicy   RetryPolicy
+	rateLimiter   RateLimiter
+	cache         Cache
+	cacheOptions  CacheOptions
+}
+
+func NewClient(language Language, apiKey string, opts ...Option) *Client {
+	client := &Client{
 		language:   language,
 		httpClient: &http.Client{},
 		apiKey:     apiKey,
+		baseURL:    defaultBaseURL,
+		userAgent:  defaultUserAgent,
+	}
+
+	for _, opt := range opts {
+		opt(client)
 	}
+
+	if client.timeout > 0 {
+		httpClient := *client.httpClient
+		httpClient.Timeout = client.timeout
+		client.httpClient = &httpClient
+	}
+
+	return client
 }
 
 func (c *Client) Fetch(ctx context.Context, method, path string, query, body, out any) error {
@@ -57,41 +152,25 @@ func (c *Client) Fetch(ctx context.Context, method, path string, query, body, ou
 		return err
 	}
 
-	request, err := c.NewRequest(ctx, method, fullURL, body)
-	if err != nil {
-		return err
+	if c.cache != nil && method == http.MethodGet {
+		if ttl := c.cacheTTL(path); ttl > 0 {
+			return c.fetchCached(ctx, path, fullURL, ttl, out)
+		}
 	}
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(ctx, method, fullURL, body, nil)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return err
 	}
 
 	defer response.Body.Close() //nolint:errcheck
 
-	decoder := json.NewDecoder(response.Body)
-
-	if response.StatusCode != http.StatusOK {
-		var apiError APIError
-		if err := decoder.Decode(&apiError); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
-		}
-
-		return &apiError
-	}
-
-	var apiResponse APIResponse[json.RawMessage]
-	if err := decoder.Decode(&apiResponse); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if out != nil {
-		if err := json.Unmarshal(apiResponse.Data, out); err != nil {
-			return fmt.Errorf("failed to unmarshal data from response: %w", err)
-		}
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
-	return nil
+	return decodeResponse(method, path, response, raw, out)
 }
 
 func (c *Client) Get(ctx context.Context, path string, params, result any) error {
@@ -99,6 +178,10 @@ func (c *Client) Get(ctx context.Context, path string, params, result any) error
 }
 
 func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body any) (*http.Request, error) {
+	return c.newRequest(ctx, method, urlStr, body, true)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, urlStr string, body any, authorize bool) (*http.Request, error) {
 	var bodyReader io.Reader
 
 	if body != nil {
@@ -115,9 +198,9 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body any
 		return nil, fmt.Errorf("failed to create new request: %w", err)
 	}
 
-	request.Header.Set("User-Agent", "go-fortnite-api/"+version)
+	request.Header.Set("User-Agent", c.userAgent)
 
-	if c.apiKey != "" {
+	if authorize && c.apiKey != "" {
 		request.Header.Set("Authorization", c.apiKey)
 	}
 
@@ -350,7 +433,7 @@ func (c *Client) GetBRStatsByID(ctx context.Context, playlistID string, params *
 }
 
 func (c *Client) buildURL(path string, query any) (string, error) {
-	fullURL, err := url.Parse(baseURL + path)
+	fullURL, err := url.Parse(c.baseURL + path)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse URL: %w", err)
 	}
@@ -371,6 +454,10 @@ func (c *Client) buildURL(path string, query any) (string, error) {
 		params.Set("language", string(c.language))
 	}
 
+	if c.responseFlags != 0 && !params.Has("responseFlags") {
+		params.Set("responseFlags", strconv.FormatUint(uint64(c.responseFlags), 10))
+	}
+
 	fullURL.RawQuery = params.Encode()
 	return fullURL.String(), nil
 }
@@ -383,6 +470,81 @@ func (c *Client) checkAPIKey() error {
 	return nil
 }
 
+func decodeResponse(method, path string, response *http.Response, raw []byte, out any) error {
+	if response.StatusCode != http.StatusOK {
+		return newAPIError(method, path, response, raw)
+	}
+
+	var envelope apiEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return &DecodeError{
+			Status:      response.StatusCode,
+			ContentType: response.Header.Get("Content-Type"),
+			Body:        bodySnippet(raw),
+			Err:         err,
+		}
+	}
+
+	if envelope.Status != 0 && envelope.Status != http.StatusOK {
+		return newAPIError(method, path, response, raw)
+	}
+
+	return unmarshalData(envelope.Data, out)
+}
+
+func unmarshalData(data json.RawMessage, out any) error {
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to unmarshal data from response: %w", err)
+	}
+
+	return nil
+}
+
+func newAPIError(method, path string, response *http.Response, raw []byte) *APIError {
+	apiError := &APIError{
+		Status:      response.StatusCode,
+		Method:      method,
+		Path:        path,
+		Header:      response.Header,
+		ContentType: response.Header.Get("Content-Type"),
+		Body:        bodySnippet(raw),
+	}
+
+	var envelope apiEnvelope
+	if err := json.Unmarshal(raw, &envelope); err == nil {
+		if envelope.Status != 0 {
+			apiError.Status = envelope.Status
+		}
+
+		apiError.Message = envelope.Error
+	}
+
+	if apiError.Message == "" {
+		apiError.Message = http.StatusText(apiError.Status)
+	}
+
+	return apiError
+}
+
+func bodySnippet(raw []byte) string {
+	const maxSize = 512
+
+	if len(raw) > maxSize {
+		raw = raw[:maxSize]
+	}
+
+	return strings.ToValidUTF8(string(raw), "")
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
 func emptyParamErr(name string) error {
 	return fmt.Errorf("%w: %s", ErrEmptyParameter, name)
 }
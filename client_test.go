@@ -3,6 +3,7 @@ package fortniteapi
 import (
 	"context"
 	"log"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -44,9 +45,32 @@ func TestMain(m *testing.M) {
 		log.Println("API_KEY is not set in .env file, skipping tests that require it")
 	}
 
-	testClient = NewClient(LanguageEnglish, apiKey)
+	var (
+		opts   []Option
+		server *httptest.Server
+	)
 
-	os.Exit(m.Run())
+	// SNAPSHOT_DIR runs the tests against a snapshot instead of the API.
+	if dir := os.Getenv("SNAPSHOT_DIR"); dir != "" {
+		handler, err := NewSnapshotHandler(dir, SnapshotHandlerOptions{})
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		server = httptest.NewServer(handler)
+
+		opts = append(opts, WithBaseURL(server.URL))
+	}
+
+	testClient = NewClient(LanguageEnglish, apiKey, opts...)
+
+	code := m.Run()
+
+	if server != nil {
+		server.Close()
+	}
+
+	os.Exit(code)
 }
 
 func Test_GetAESKey(t *testing.T) {
@@ -0,0 +1,320 @@
+package fortniteapi
+
+import (
+	"iter"
+	"time"
+)
+
+// Cosmetic is implemented by pointers to every cosmetic type, so code can
+// handle all of them without a type switch.
+type Cosmetic interface {
+	ItemID() string
+	DisplayName() string
+	Kind() CosmeticCategory
+
+	// RarityValue and SeriesValue are empty for types without them.
+	RarityValue() string
+	SeriesValue() string
+
+	// BestImageURL returns the largest image available, or "".
+	BestImageURL() string
+
+	// AddedTime reports false when the date is missing or invalid.
+	AddedTime() (time.Time, bool)
+
+	// ShopHistoryTimes is sorted oldest first, and empty for types the
+	// shop doesn't sell.
+	ShopHistoryTimes() []time.Time
+}
+
+var (
+	_ Cosmetic = (*BRCosmetic)(nil)
+	_ Cosmetic = (*Track)(nil)
+	_ Cosmetic = (*Instrument)(nil)
+	_ Cosmetic = (*Car)(nil)
+	_ Cosmetic = (*Lego)(nil)
+	_ Cosmetic = (*LegoKit)(nil)
+	_ Cosmetic = (*Bean)(nil)
+)
+
+// Iter yields every cosmetic of the response, category by category in the
+// order of CosmeticCategories.
+func (r *AllCosmeticsResponse) Iter() iter.Seq[Cosmetic] {
+	return func(yield func(Cosmetic) bool) {
+		for i := range r.BR {
+			if !yield(&r.BR[i]) {
+				return
+			}
+		}
+
+		for i := range r.Tracks {
+			if !yield(&r.Tracks[i]) {
+				return
+			}
+		}
+
+		for i := range r.Instruments {
+			if !yield(&r.Instruments[i]) {
+				return
+			}
+		}
+
+		for i := range r.Cars {
+			if !yield(&r.Cars[i]) {
+				return
+			}
+		}
+
+		for i := range r.Lego {
+			if !yield(&r.Lego[i]) {
+				return
+			}
+		}
+
+		for i := range r.LegoKits {
+			if !yield(&r.LegoKits[i]) {
+				return
+			}
+		}
+
+		for i := range r.Beans {
+			if !yield(&r.Beans[i]) {
+				return
+			}
+		}
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func (c *BRCosmetic) ItemID() string {
+	return c.ID
+}
+
+func (c *BRCosmetic) DisplayName() string {
+	return c.Name
+}
+
+func (c *BRCosmetic) Kind() CosmeticCategory {
+	return CosmeticCategoryBR
+}
+
+func (c *BRCosmetic) RarityValue() string {
+	return c.Rarity.Value
+}
+
+func (c *BRCosmetic) SeriesValue() string {
+	return c.Series.Value
+}
+
+func (c *BRCosmetic) AddedTime() (time.Time, bool) {
+	return parseAPITime(c.Added)
+}
+
+func (c *BRCosmetic) BestImageURL() string {
+	return firstNonEmpty(c.Images.Featured, c.Images.Icon, c.Images.SmallIcon)
+}
+
+func (c *BRCosmetic) ShopHistoryTimes() []time.Time {
+	return c.ShopHistory.Times()
+}
+
+func (t *Track) ItemID() string {
+	return t.ID
+}
+
+func (t *Track) DisplayName() string {
+	return t.Title
+}
+
+func (t *Track) Kind() CosmeticCategory {
+	return CosmeticCategoryTracks
+}
+
+func (t *Track) RarityValue() string {
+	return ""
+}
+
+func (t *Track) SeriesValue() string {
+	return ""
+}
+
+func (t *Track) BestImageURL() string {
+	return t.AlbumArt
+}
+
+func (t *Track) AddedTime() (time.Time, bool) {
+	return parseAPITime(t.Added)
+}
+
+func (t *Track) ShopHistoryTimes() []time.Time {
+	return t.ShopHistory.Times()
+}
+
+func (i *Instrument) ItemID() string {
+	return i.ID
+}
+
+func (i *Instrument) DisplayName() string {
+	return i.Name
+}
+
+func (i *Instrument) Kind() CosmeticCategory {
+	return CosmeticCategoryInstruments
+}
+
+func (i *Instrument) RarityValue() string {
+	return i.Rarity.Value
+}
+
+func (i *Instrument) SeriesValue() string {
+	return i.Series.Value
+}
+
+func (i *Instrument) BestImageURL() string {
+	return firstNonEmpty(i.Images.Large, i.Images.Small)
+}
+
+func (i *Instrument) AddedTime() (time.Time, bool) {
+	return parseAPITime(i.Added)
+}
+
+func (i *Instrument) ShopHistoryTimes() []time.Time {
+	return i.ShopHistory.Times()
+}
+
+func (c *Car) ItemID() string {
+	return c.ID
+}
+
+func (c *Car) DisplayName() string {
+	return c.Name
+}
+
+func (c *Car) Kind() CosmeticCategory {
+	return CosmeticCategoryCars
+}
+
+func (c *Car) RarityValue() string {
+	return c.Rarity.Value
+}
+
+func (c *Car) SeriesValue() string {
+	return c.Series.Value
+}
+
+func (c *Car) BestImageURL() string {
+	return firstNonEmpty(c.Images.Large, c.Images.Small)
+}
+
+func (c *Car) AddedTime() (time.Time, bool) {
+	return parseAPITime(c.Added)
+}
+
+func (c *Car) ShopHistoryTimes() []time.Time {
+	return c.ShopHistory.Times()
+}
+
+func (l *Lego) ItemID() string {
+	return l.ID
+}
+
+func (l *Lego) DisplayName() string {
+	return l.Name
+}
+
+func (l *Lego) Kind() CosmeticCategory {
+	return CosmeticCategoryLego
+}
+
+func (l *Lego) RarityValue() string {
+	return ""
+}
+
+func (l *Lego) SeriesValue() string {
+	return ""
+}
+
+func (l *Lego) BestImageURL() string {
+	return firstNonEmpty(l.Images.Large, l.Images.Wide, l.Images.Small)
+}
+
+func (l *Lego) AddedTime() (time.Time, bool) {
+	return parseAPITime(l.Added)
+}
+
+func (l *Lego) ShopHistoryTimes() []time.Time {
+	return nil
+}
+
+func (k *LegoKit) ItemID() string {
+	return k.ID
+}
+
+func (k *LegoKit) DisplayName() string {
+	return k.Name
+}
+
+func (k *LegoKit) Kind() CosmeticCategory {
+	return CosmeticCategoryLegoKits
+}
+
+func (k *LegoKit) RarityValue() string {
+	return ""
+}
+
+func (k *LegoKit) SeriesValue() string {
+	return k.Series.Value
+}
+
+func (k *LegoKit) BestImageURL() string {
+	return firstNonEmpty(k.Images.Large, k.Images.Wide, k.Images.Small)
+}
+
+func (k *LegoKit) AddedTime() (time.Time, bool) {
+	return parseAPITime(k.Added)
+}
+
+func (k *LegoKit) ShopHistoryTimes() []time.Time {
+	return k.ShopHistory.Times()
+}
+
+func (b *Bean) ItemID() string {
+	return b.ID
+}
+
+func (b *Bean) DisplayName() string {
+	return b.Name
+}
+
+func (b *Bean) Kind() CosmeticCategory {
+	return CosmeticCategoryBeans
+}
+
+func (b *Bean) RarityValue() string {
+	return ""
+}
+
+func (b *Bean) SeriesValue() string {
+	return ""
+}
+
+func (b *Bean) BestImageURL() string {
+	return firstNonEmpty(b.Images.Large, b.Images.Small)
+}
+
+func (b *Bean) AddedTime() (time.Time, bool) {
+	return parseAPITime(b.Added)
+}
+
+func (b *Bean) ShopHistoryTimes() []time.Time {
+	return nil
+}
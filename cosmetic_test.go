@@ -0,0 +1,97 @@
+package fortniteapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AllCosmeticsResponse_Iter(t *testing.T) {
+	t.Parallel()
+
+	response := &AllCosmeticsResponse{
+		BR: []BRCosmetic{{
+			ID:          "CID_001",
+			Name:        "Renegade Raider",
+			Rarity:      BRCosmeticRarity{Value: "rare"},
+			Images:      BRCosmeticImages{SmallIcon: "small", Icon: "icon"},
+			Added:       "2017-10-26T00:00:00Z",
+			ShopHistory: []string{"2018-01-01T00:00:00Z", "invalid"},
+		}},
+		Tracks: []Track{{ID: "track", Title: "Song", AlbumArt: "art"}},
+		Beans:  []Bean{{ID: "bean", CosmeticID: "CID_001", Name: "Bean", Images: BeanImages{Small: "small"}}},
+	}
+
+	var cosmetics []Cosmetic
+	for cosmetic := range response.Iter() {
+		cosmetics = append(cosmetics, cosmetic)
+	}
+
+	require.Len(t, cosmetics, 3)
+
+	br := cosmetics[0]
+	assert.Equal(t, "CID_001", br.ItemID())
+	assert.Equal(t, CosmeticCategoryBR, br.Kind())
+	assert.Equal(t, "rare", br.RarityValue())
+	assert.Equal(t, "icon", br.BestImageURL())
+	added, ok := br.AddedTime()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2017, 10, 26, 0, 0, 0, 0, time.UTC), added)
+	assert.Len(t, br.ShopHistoryTimes(), 1)
+
+	assert.Equal(t, "Song", cosmetics[1].DisplayName())
+	assert.Equal(t, "art", cosmetics[1].BestImageURL())
+	_, ok = cosmetics[1].AddedTime()
+	assert.False(t, ok)
+
+	assert.Equal(t, CosmeticCategoryBeans, cosmetics[2].Kind())
+	assert.Equal(t, "small", cosmetics[2].BestImageURL())
+}
+
+func Test_ShopHistory(t *testing.T) {
+	t.Parallel()
+
+	history := ShopHistory{"2025-03-01T00:00:00Z", "", "2024-12-24T00:00:00Z", "not a date", "2025-01-15"}
+
+	first, ok := history.FirstSeen()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC), first)
+
+	last, ok := history.LastSeenInShop()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), last)
+
+	assert.Equal(t, 3, history.TimesInShop())
+
+	days, ok := history.DaysSinceLastAppearance(time.Date(2025, 3, 11, 18, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, 10, days)
+
+	_, ok = ShopHistory{"invalid"}.LastSeenInShop()
+	assert.False(t, ok)
+
+	cosmetic := BRCosmetic{ShopHistory: history}
+	assert.Equal(t, 3, cosmetic.ShopHistory.TimesInShop())
+	assert.Len(t, cosmetic.ShopHistoryTimes(), 3)
+	assert.Equal(t, history.Times(), cosmetic.ShopHistoryTimes())
+}
+
+func Test_ParsedDates(t *testing.T) {
+	t.Parallel()
+
+	added, ok := (&Playlist{Added: "2023-06-01T12:00:00Z"}).AddedTime()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC), added)
+
+	_, ok = (&Playlist{}).AddedTime()
+	assert.False(t, ok)
+
+	modified, ok := BRStatsData{LastModified: "2024-02-03T04:05:06.789Z"}.LastModifiedTime()
+	require.True(t, ok)
+	assert.Equal(t, 2024, modified.Year())
+
+	_, ok = BRStatsData{LastModified: "garbage"}.LastModifiedTime()
+	assert.False(t, ok)
+}
@@ -0,0 +1,388 @@
+package fortniteapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	MatchMethodFull     = "full"
+	MatchMethodContains = "contains"
+	MatchMethodStarts   = "starts"
+	MatchMethodEnds     = "ends"
+)
+
+// foldCase lowercases s with the rules of language, so that for example
+// a Turkish "I" matches "ı" instead of "i".
+func foldCase(language Language, s string) string {
+	switch language {
+	case LanguageTurkish:
+		return strings.ToLowerSpecial(unicode.TurkishCase, s)
+	default:
+		return strings.ToLower(s)
+	}
+}
+
+// sameFolding reports whether foldCase folds the same way for a and b.
+func sameFolding(a, b Language) bool {
+	return (a == LanguageTurkish) == (b == LanguageTurkish)
+}
+
+func matchString(method, value, query string) bool {
+	switch method {
+	case MatchMethodContains:
+		return strings.Contains(value, query)
+	case MatchMethodStarts:
+		return strings.HasPrefix(value, query)
+	case MatchMethodEnds:
+		return strings.HasSuffix(value, query)
+	default:
+		return value == query
+	}
+}
+
+type CosmeticIndexOptions struct {
+	// Language of the indexed cosmetics, used for case folding. Without
+	// it, each query folds with its SearchLanguage or Language.
+	//
+	// Default: *none*
+	Language Language
+
+	Clock Clock
+}
+
+// indexedField is a string field of BRCosmetic that queries filter on.
+type indexedField int
+
+const (
+	fieldName indexedField = iota
+	fieldDescription
+	fieldID
+	fieldType
+	fieldDisplayType
+	fieldBackendType
+	fieldRarity
+	fieldDisplayRarity
+	fieldBackendRarity
+	fieldSeries
+	fieldBackendSeries
+	fieldSet
+	fieldSetText
+	fieldBackendSet
+	fieldIntroductionChapter
+	fieldIntroductionSeason
+	fieldDynamicPakID
+
+	indexedFieldCount
+)
+
+type indexedFields [indexedFieldCount]string
+
+func cosmeticFields(c BRCosmetic) indexedFields {
+	return indexedFields{
+		fieldName:                c.Name,
+		fieldDescription:         c.Description,
+		fieldID:                  c.ID,
+		fieldType:                c.Type.Value,
+		fieldDisplayType:         c.Type.DisplayValue,
+		fieldBackendType:         c.Type.BackendValue,
+		fieldRarity:              c.Rarity.Value,
+		fieldDisplayRarity:       c.Rarity.DisplayValue,
+		fieldBackendRarity:       c.Rarity.BackendValue,
+		fieldSeries:              c.Series.Value,
+		fieldBackendSeries:       c.Series.BackendValue,
+		fieldSet:                 c.Set.Value,
+		fieldSetText:             c.Set.Text,
+		fieldBackendSet:          c.Set.BackendValue,
+		fieldIntroductionChapter: c.Introduction.Chapter,
+		fieldIntroductionSeason:  c.Introduction.Season,
+		fieldDynamicPakID:        c.DynamicPakID,
+	}
+}
+
+func queryFields(p *SearchBRCosmeticParams) indexedFields {
+	return indexedFields{
+		fieldName:                p.Name,
+		fieldDescription:         p.Description,
+		fieldID:                  p.ID,
+		fieldType:                p.Type,
+		fieldDisplayType:         p.DisplayType,
+		fieldBackendType:         p.BackendType,
+		fieldRarity:              p.Rarity,
+		fieldDisplayRarity:       p.DisplayRarity,
+		fieldBackendRarity:       p.BackendRarity,
+		fieldSeries:              p.Series,
+		fieldBackendSeries:       p.BackendSeries,
+		fieldSet:                 p.Set,
+		fieldSetText:             p.SetText,
+		fieldBackendSet:          p.BackendSet,
+		fieldIntroductionChapter: p.IntroductionChapter,
+		fieldIntroductionSeason:  p.IntroductionSeason,
+		fieldDynamicPakID:        p.DynamicPakID,
+	}
+}
+
+func (f *indexedFields) fold(language Language) {
+	for i, value := range f {
+		f[i] = foldCase(language, value)
+	}
+}
+
+// indexedCosmetic holds the string fields of a cosmetic folded once, so
+// searches don't fold them again.
+type indexedCosmetic struct {
+	fields       indexedFields
+	gameplayTags []string
+	metaTags     []string
+	added        time.Time
+	lastSeen     time.Time
+}
+
+func (c *indexedCosmetic) fold(language Language, cosmetic BRCosmetic) {
+	c.fields = cosmeticFields(cosmetic)
+	c.fields.fold(language)
+	c.gameplayTags = foldAll(language, cosmetic.GameplayTags)
+	c.metaTags = foldAll(language, cosmetic.MetaTags)
+}
+
+func foldAll(language Language, values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	folded := make([]string, len(values))
+	for i, value := range values {
+		folded[i] = foldCase(language, value)
+	}
+
+	return folded
+}
+
+// CosmeticIndex answers BR cosmetic searches in memory, with the filters of
+// SearchBRCosmeticParams.
+type CosmeticIndex struct {
+	options   CosmeticIndexOptions
+	cosmetics []BRCosmetic
+	indexed   []indexedCosmetic
+	byID      map[string]int
+
+	// folded is the language the indexed names were folded with.
+	folded Language
+}
+
+func NewCosmeticIndex(cosmetics []BRCosmetic, options CosmeticIndexOptions) *CosmeticIndex {
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	index := &CosmeticIndex{
+		options:   options,
+		cosmetics: cosmetics,
+		indexed:   make([]indexedCosmetic, len(cosmetics)),
+		byID:      make(map[string]int, len(cosmetics)),
+	}
+
+	index.folded = index.language(nil)
+
+	for i, cosmetic := range cosmetics {
+		var entry indexedCosmetic
+		entry.fold(index.folded, cosmetic)
+		entry.added, _ = cosmetic.AddedTime()
+		entry.lastSeen, _ = cosmetic.ShopHistory.LastSeenInShop()
+
+		index.indexed[i] = entry
+		index.byID[strings.ToLower(cosmetic.ID)] = i
+	}
+
+	return index
+}
+
+// NewCosmeticIndexFromResponse indexes the BR cosmetics of a response.
+func NewCosmeticIndexFromResponse(response *AllCosmeticsResponse, options CosmeticIndexOptions) *CosmeticIndex {
+	return NewCosmeticIndex(response.BR, options)
+}
+
+func (x *CosmeticIndex) Len() int {
+	return len(x.cosmetics)
+}
+
+// ByID returns the cosmetic with the given ID, ignoring case.
+func (x *CosmeticIndex) ByID(id string) (BRCosmetic, bool) {
+	i, ok := x.byID[strings.ToLower(id)]
+	if !ok {
+		return BRCosmetic{}, false
+	}
+
+	return x.cosmetics[i], true
+}
+
+// Search returns every cosmetic matching params, in index order.
+//
+// MatchMethod applies to every string filter. The date filters take Unix
+// timestamps: Added and LastAppearance match the same UTC day, AddedSince
+// matches that time or later, and UnseenFor is the number of seconds since
+// the last shop appearance.
+func (x *CosmeticIndex) Search(params *SearchBRCosmeticsParams) ([]BRCosmetic, error) {
+	var results []BRCosmetic
+
+	err := x.search((*SearchBRCosmeticParams)(params), func(cosmetic BRCosmetic) bool {
+		results = append(results, cosmetic)
+		return true
+	})
+
+	return results, err
+}
+
+// SearchOne returns the first cosmetic matching params.
+func (x *CosmeticIndex) SearchOne(params *SearchBRCosmeticParams) (BRCosmetic, bool, error) {
+	var (
+		result BRCosmetic
+		found  bool
+	)
+
+	err := x.search(params, func(cosmetic BRCosmetic) bool {
+		result, found = cosmetic, true
+		return false
+	})
+
+	return result, found, err
+}
+
+func (x *CosmeticIndex) search(params *SearchBRCosmeticParams, yield func(BRCosmetic) bool) error {
+	if params == nil {
+		params = &SearchBRCosmeticParams{}
+	}
+
+	switch params.MatchMethod {
+	case "", MatchMethodFull, MatchMethodContains, MatchMethodStarts, MatchMethodEnds:
+	default:
+		return fmt.Errorf("invalid match method %q", params.MatchMethod)
+	}
+
+	query := x.newQuery(params)
+
+	for i, cosmetic := range x.cosmetics {
+		if query.matches(cosmetic, x.indexed[i]) && !yield(cosmetic) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (x *CosmeticIndex) language(params *SearchBRCosmeticParams) Language {
+	switch {
+	case x.options.Language != "":
+		return x.options.Language
+	case params != nil && params.SearchLanguage != "":
+		return params.SearchLanguage
+	case params != nil && params.Language != "":
+		return params.Language
+	default:
+		return LanguageEnglish
+	}
+}
+
+type cosmeticQuery struct {
+	*SearchBRCosmeticParams
+
+	language Language
+	refold   bool
+	now      time.Time
+
+	// filters are the string filters of the params, folded once.
+	filters     indexedFields
+	gameplayTag string
+	metaTag     string
+}
+
+func (x *CosmeticIndex) newQuery(params *SearchBRCosmeticParams) *cosmeticQuery {
+	language := x.language(params)
+
+	query := &cosmeticQuery{
+		SearchBRCosmeticParams: params,
+		language:               language,
+		refold:                 !sameFolding(language, x.folded),
+		now:                    x.options.Clock.Now(),
+		filters:                queryFields(params),
+		gameplayTag:            foldCase(language, params.GameplayTag),
+		metaTag:                foldCase(language, params.MetaTag),
+	}
+
+	query.filters.fold(language)
+	return query
+}
+
+func (q *cosmeticQuery) matchAny(values []string, filter string) bool {
+	if filter == "" {
+		return true
+	}
+
+	for _, value := range values {
+		if matchString(q.MatchMethod, value, filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (q *cosmeticQuery) matches(c BRCosmetic, indexed indexedCosmetic) bool {
+	if q.refold {
+		indexed.fold(q.language, c)
+	}
+
+	return q.matchFlags(c) &&
+		q.matchFields(c, indexed) &&
+		q.matchDates(indexed)
+}
+
+func (q *cosmeticQuery) matchFlags(c BRCosmetic) bool {
+	return (!q.HasSeries || c.Series.BackendValue != "") &&
+		(!q.HasSet || c.Set.BackendValue != "") &&
+		(!q.HasIntroduction || c.Introduction.BackendValue != 0) &&
+		(!q.HasFeaturedImage || c.Images.Featured != "") &&
+		(!q.HasVariants || len(c.Variants) > 0) &&
+		(!q.HasGameplayTags || len(c.GameplayTags) > 0) &&
+		(!q.HasMetaTags || len(c.MetaTags) > 0) &&
+		(!q.HasDynamicPakID || c.DynamicPakID != "")
+}
+
+func (q *cosmeticQuery) matchFields(c BRCosmetic, indexed indexedCosmetic) bool {
+	for field, filter := range q.filters {
+		if filter != "" && !matchString(q.MatchMethod, indexed.fields[field], filter) {
+			return false
+		}
+	}
+
+	return (q.BackendIntroduction == 0 || c.Introduction.BackendValue == q.BackendIntroduction) &&
+		q.matchAny(indexed.gameplayTags, q.gameplayTag) &&
+		q.matchAny(indexed.metaTags, q.metaTag)
+}
+
+func (q *cosmeticQuery) matchDates(indexed indexedCosmetic) bool {
+	if q.Added != 0 && (indexed.added.IsZero() || !sameDay(indexed.added, time.Unix(int64(q.Added), 0))) {
+		return false
+	}
+
+	if q.AddedSince != 0 && (indexed.added.IsZero() || indexed.added.Unix() < int64(q.AddedSince)) {
+		return false
+	}
+
+	if q.LastAppearance != 0 && (indexed.lastSeen.IsZero() || !sameDay(indexed.lastSeen, time.Unix(int64(q.LastAppearance), 0))) {
+		return false
+	}
+
+	if q.UnseenFor != 0 && (indexed.lastSeen.IsZero() || q.now.Sub(indexed.lastSeen) < time.Duration(q.UnseenFor)*time.Second) {
+		return false
+	}
+
+	return true
+}
+
+func sameDay(a, b time.Time) bool {
+	a, b = a.UTC(), b.UTC()
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
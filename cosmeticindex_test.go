@@ -0,0 +1,126 @@
+package fortniteapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndexCosmetics() []BRCosmetic {
+	return []BRCosmetic{
+		{
+			ID:           "CID_001",
+			Name:         "Renegade Raider",
+			Type:         BRCosmeticType{Value: "outfit", BackendValue: "AthenaCharacter"},
+			Rarity:       BRCosmeticRarity{Value: "rare"},
+			Introduction: BRCosmeticIntroduction{Chapter: "1", Season: "1", BackendValue: 1},
+			GameplayTags: []string{"Cosmetics.Source.ItemShop"},
+			Added:        "2017-10-26T00:00:00Z",
+			ShopHistory:  []string{"2018-01-01T00:00:00Z", "2024-01-01T00:00:00Z"},
+		},
+		{
+			ID:     "Pickaxe_Raider",
+			Name:   "Raider's Revenge",
+			Type:   BRCosmeticType{Value: "pickaxe"},
+			Rarity: BRCosmeticRarity{Value: "epic"},
+			Set:    BRCosmeticSet{Value: "Raider", BackendValue: "Raider"},
+			Added:  "2018-02-01T00:00:00Z",
+		},
+		{
+			ID:    "CID_IRMAK",
+			Name:  "IRMAK",
+			Type:  BRCosmeticType{Value: "outfit"},
+			Added: "2024-05-01T00:00:00Z",
+		},
+	}
+}
+
+func Test_CosmeticIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	index := NewCosmeticIndex(testIndexCosmetics(), CosmeticIndexOptions{
+		Clock: newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+
+	results, err := index.Search(&SearchBRCosmeticsParams{Name: "raider", MatchMethod: MatchMethodContains})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, err = index.Search(&SearchBRCosmeticsParams{Name: "renegade raider"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "CID_001", results[0].ID)
+
+	results, err = index.Search(&SearchBRCosmeticsParams{Type: "outfit", HasIntroduction: true, GameplayTag: "cosmetics.source.itemshop"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	results, err = index.Search(&SearchBRCosmeticsParams{HasSet: true, Rarity: "EPIC"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Pickaxe_Raider", results[0].ID)
+
+	results, err = index.Search(&SearchBRCosmeticsParams{AddedSince: int(time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC).Unix())})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, err = index.Search(&SearchBRCosmeticsParams{UnseenFor: int((200 * 24 * time.Hour).Seconds())})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	cosmetic, ok, err := index.SearchOne(&SearchBRCosmeticParams{Name: "revenge", MatchMethod: MatchMethodEnds})
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Pickaxe_Raider", cosmetic.ID)
+
+	_, err = index.Search(&SearchBRCosmeticsParams{MatchMethod: "fuzzy"})
+	require.Error(t, err)
+
+	cosmetic, ok = index.ByID("cid_001")
+	require.True(t, ok)
+	assert.Equal(t, "Renegade Raider", cosmetic.Name)
+}
+
+func Test_CosmeticIndex_TurkishCaseFolding(t *testing.T) {
+	t.Parallel()
+
+	index := NewCosmeticIndex(testIndexCosmetics(), CosmeticIndexOptions{})
+
+	results, err := index.Search(&SearchBRCosmeticsParams{Name: "ırmak", SearchLanguage: LanguageTurkish})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	results, err = index.Search(&SearchBRCosmeticsParams{Name: "ırmak"})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func Test_CosmeticIndex_SearchDoesNotFoldPerCosmetic(t *testing.T) {
+	cosmetics := testIndexCosmetics()
+	for range 100 {
+		cosmetics = append(cosmetics, cosmetics[:3]...)
+	}
+
+	// Without a Language the index folds in English and each query picks its
+	// own. German folds like English, so its queries reuse the index too.
+	index := NewCosmeticIndex(cosmetics, CosmeticIndexOptions{})
+
+	for _, language := range []Language{LanguageEnglish, LanguageGerman} {
+		params := &SearchBRCosmeticParams{
+			SearchLanguage: language,
+			Rarity:         "Legendary",
+			GameplayTag:    "cosmetics.source",
+			MatchMethod:    MatchMethodStarts,
+		}
+
+		allocs := testing.AllocsPerRun(10, func() {
+			_, _, err := index.SearchOne(params)
+			require.NoError(t, err)
+		})
+
+		// Only the query itself allocates, not the cosmetics it scans.
+		assert.Less(t, allocs, float64(len(cosmetics)), language)
+	}
+}
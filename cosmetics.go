@@ -92,7 +92,7 @@ type BRCosmetic struct {
 	DefinitionPath         string                  `json:"definitionPath,omitempty"`
 	Path                   string                  `json:"path,omitempty"`
 	Added                  string                  `json:"added"`
-	ShopHistory            []string                `json:"shopHistory,omitempty"`
+	ShopHistory            ShopHistory             `json:"shopHistory,omitempty"`
 }
 
 type TrackDifficulty struct {
@@ -118,7 +118,7 @@ type Track struct {
 	Genres       []string        `json:"genres"`
 	AlbumArt     string          `json:"albumArt"`
 	Added        string          `json:"added,omitempty"`
-	ShopHistory  []string        `json:"shopHistory,omitempty"`
+	ShopHistory  ShopHistory     `json:"shopHistory,omitempty"`
 }
 
 type InstrumentImages struct {
@@ -138,7 +138,7 @@ type Instrument struct {
 	Path          string           `json:"path"`
 	ShowcaseVideo string           `json:"showcaseVideo"`
 	Added         string           `json:"added"`
-	ShopHistory   []string         `json:"shopHistory,omitempty"`
+	ShopHistory   ShopHistory      `json:"shopHistory,omitempty"`
 }
 
 type CarImages struct {
@@ -159,7 +159,7 @@ type Car struct {
 	Path          string           `json:"path,omitempty"`
 	ShowcaseVideo string           `json:"showcaseVideo"`
 	Added         string           `json:"added"`
-	ShopHistory   []string         `json:"shopHistory,omitempty"`
+	ShopHistory   ShopHistory      `json:"shopHistory,omitempty"`
 }
 
 type LegoImages struct {
@@ -192,7 +192,7 @@ type LegoKit struct {
 	Images       LegoKitsImages   `json:"images"`
 	Path         string           `json:"path,omitempty"`
 	Added        string           `json:"added"`
-	ShopHistory  []string         `json:"shopHistory,omitempty"`
+	ShopHistory  ShopHistory      `json:"shopHistory,omitempty"`
 }
 
 type BeanImages struct {
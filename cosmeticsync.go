@@ -0,0 +1,333 @@
+package fortniteapi
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type CosmeticCategory string
+
+const (
+	CosmeticCategoryBR          CosmeticCategory = "br"
+	CosmeticCategoryTracks      CosmeticCategory = "tracks"
+	CosmeticCategoryInstruments CosmeticCategory = "instruments"
+	CosmeticCategoryCars        CosmeticCategory = "cars"
+	CosmeticCategoryLego        CosmeticCategory = "lego"
+	CosmeticCategoryLegoKits    CosmeticCategory = "legoKits"
+	CosmeticCategoryBeans       CosmeticCategory = "beans"
+)
+
+var CosmeticCategories = []CosmeticCategory{
+	CosmeticCategoryBR,
+	CosmeticCategoryTracks,
+	CosmeticCategoryInstruments,
+	CosmeticCategoryCars,
+	CosmeticCategoryLego,
+	CosmeticCategoryLegoKits,
+	CosmeticCategoryBeans,
+}
+
+func (h NewCosmeticsHashes) Category(category CosmeticCategory) string {
+	switch category {
+	case CosmeticCategoryBR:
+		return h.BR
+	case CosmeticCategoryTracks:
+		return h.Tracks
+	case CosmeticCategoryInstruments:
+		return h.Instruments
+	case CosmeticCategoryCars:
+		return h.Cars
+	case CosmeticCategoryLego:
+		return h.Lego
+	case CosmeticCategoryLegoKits:
+		return h.LegoKits
+	case CosmeticCategoryBeans:
+		return h.Beans
+	default:
+		return ""
+	}
+}
+
+func (h *NewCosmeticsHashes) setCategory(category CosmeticCategory, hash string) {
+	switch category {
+	case CosmeticCategoryBR:
+		h.BR = hash
+	case CosmeticCategoryTracks:
+		h.Tracks = hash
+	case CosmeticCategoryInstruments:
+		h.Instruments = hash
+	case CosmeticCategoryCars:
+		h.Cars = hash
+	case CosmeticCategoryLego:
+		h.Lego = hash
+	case CosmeticCategoryLegoKits:
+		h.LegoKits = hash
+	case CosmeticCategoryBeans:
+		h.Beans = hash
+	}
+}
+
+type CosmeticEventType string
+
+const (
+	CosmeticAdded   CosmeticEventType = "added"
+	CosmeticRemoved CosmeticEventType = "removed"
+	CosmeticChanged CosmeticEventType = "changed"
+)
+
+// CosmeticEvent describes a single cosmetic that changed between two syncs.
+// Old and New hold the category's item type, for example BRCosmetic or
+// Track, and are nil for added and removed items respectively.
+type CosmeticEvent struct {
+	Type     CosmeticEventType
+	Category CosmeticCategory
+	ID       string
+	Old      any
+	New      any
+}
+
+type CosmeticsSyncerOptions struct {
+	Language      Language
+	ResponseFlags ResponseFlag
+
+	// Interval is the time between two syncs in Run.
+	//
+	// Default: 10 minutes
+	Interval time.Duration
+
+	Clock Clock
+
+	// OnError is called by Run when a sync fails. Run keeps polling either way.
+	OnError func(error)
+}
+
+// CosmeticsSyncer keeps a local AllCosmeticsResponse up to date by polling
+// the new cosmetics endpoint and only refetching categories whose hash
+// changed.
+type CosmeticsSyncer struct {
+	client  *Client
+	options CosmeticsSyncerOptions
+
+	// syncMu serializes syncs, so mu is only held to read and swap the state
+	// and Snapshot doesn't wait for the network.
+	syncMu sync.Mutex
+
+	mu       sync.Mutex
+	snapshot AllCosmeticsResponse
+	hashes   NewCosmeticsHashes
+}
+
+func NewCosmeticsSyncer(client *Client, options CosmeticsSyncerOptions) *CosmeticsSyncer {
+	if options.Interval <= 0 {
+		options.Interval = 10 * time.Minute
+	}
+
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	return &CosmeticsSyncer{
+		client:  client,
+		options: options,
+	}
+}
+
+// Load seeds the syncer with a previously saved snapshot and its hashes,
+// so the first Sync only reports what changed since then.
+func (s *CosmeticsSyncer) Load(snapshot AllCosmeticsResponse, hashes NewCosmeticsHashes) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshot = snapshot
+	s.hashes = hashes
+}
+
+func (s *CosmeticsSyncer) Snapshot() (AllCosmeticsResponse, NewCosmeticsHashes) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.snapshot, s.hashes
+}
+
+func (s *CosmeticsSyncer) Sync(ctx context.Context) ([]CosmeticEvent, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	snapshot, hashes := s.Snapshot()
+
+	latest, err := s.client.GetNewCosmetics(ctx, &NewCosmeticsParams{
+		Language:      s.options.Language,
+		ResponseFlags: s.options.ResponseFlags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []CosmeticEvent
+
+	for _, category := range CosmeticCategories {
+		hash := latest.Hashes.Category(category)
+		if hash == hashes.Category(category) {
+			continue
+		}
+
+		categoryEvents, err := s.syncCategory(ctx, category, &snapshot)
+		if err != nil {
+			// Keep the categories that were synced before the failure.
+			s.swap(snapshot, hashes)
+			return events, err
+		}
+
+		hashes.setCategory(category, hash)
+		events = append(events, categoryEvents...)
+	}
+
+	hashes.All = latest.Hashes.All
+
+	s.swap(snapshot, hashes)
+	return events, nil
+}
+
+func (s *CosmeticsSyncer) swap(snapshot AllCosmeticsResponse, hashes NewCosmeticsHashes) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshot = snapshot
+	s.hashes = hashes
+}
+
+func (s *CosmeticsSyncer) Run(ctx context.Context, handler func(CosmeticEvent)) error {
+	return runPolls(ctx, s.options.Clock, s.Sync, every(s.options.Interval), s.options.OnError, handler)
+}
+
+// syncCategory fetches a category and replaces it in snapshot, which is a
+// copy of the syncer's state.
+func (s *CosmeticsSyncer) syncCategory(ctx context.Context, category CosmeticCategory, snapshot *AllCosmeticsResponse) ([]CosmeticEvent, error) {
+	language := LanguageParams{Language: s.options.Language, ResponseFlags: s.options.ResponseFlags}
+	flags := ResponseFlagsParams{ResponseFlags: s.options.ResponseFlags}
+
+	switch category {
+	case CosmeticCategoryBR:
+		params := BRCosmeticsListParams(language)
+
+		items, err := s.client.GetBRCosmeticsList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		events := diffCosmetics(category, snapshot.BR, *items, func(item BRCosmetic) string { return item.ID })
+		snapshot.BR = *items
+
+		return events, nil
+	case CosmeticCategoryTracks:
+		params := TrackCosmeticsListParams(flags)
+
+		items, err := s.client.GetTrackCosmeticsList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		events := diffCosmetics(category, snapshot.Tracks, *items, func(item Track) string { return item.ID })
+		snapshot.Tracks = *items
+
+		return events, nil
+	case CosmeticCategoryInstruments:
+		params := InstrumentCosmeticsListParams(language)
+
+		items, err := s.client.GetInstrumentCosmeticsList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		events := diffCosmetics(category, snapshot.Instruments, *items, func(item Instrument) string { return item.ID })
+		snapshot.Instruments = *items
+
+		return events, nil
+	case CosmeticCategoryCars:
+		params := CarCosmeticsListParams(language)
+
+		items, err := s.client.GetCarCosmeticsList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		events := diffCosmetics(category, snapshot.Cars, *items, func(item Car) string { return item.ID })
+		snapshot.Cars = *items
+
+		return events, nil
+	case CosmeticCategoryLego:
+		params := LegoCosmeticsListParams(flags)
+
+		items, err := s.client.GetLegoCosmeticsList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		events := diffCosmetics(category, snapshot.Lego, *items, func(item Lego) string { return item.ID })
+		snapshot.Lego = *items
+
+		return events, nil
+	case CosmeticCategoryLegoKits:
+		params := LegoKitCosmeticsListParams(language)
+
+		items, err := s.client.GetLegoKitCosmeticsList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		events := diffCosmetics(category, snapshot.LegoKits, *items, func(item LegoKit) string { return item.ID })
+		snapshot.LegoKits = *items
+
+		return events, nil
+	case CosmeticCategoryBeans:
+		params := BeanCosmeticsListParams(language)
+
+		items, err := s.client.GetBeanCosmeticsList(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		events := diffCosmetics(category, snapshot.Beans, *items, func(item Bean) string { return item.ID })
+		snapshot.Beans = *items
+
+		return events, nil
+	default:
+		return nil, nil
+	}
+}
+
+func diffCosmetics[T any](category CosmeticCategory, oldItems, newItems []T, id func(T) string) []CosmeticEvent {
+	previous := make(map[string]T, len(oldItems))
+	for _, item := range oldItems {
+		previous[id(item)] = item
+	}
+
+	var events []CosmeticEvent
+
+	for _, item := range newItems {
+		itemID := id(item)
+
+		old, ok := previous[itemID]
+		delete(previous, itemID)
+
+		switch {
+		case !ok:
+			events = append(events, CosmeticEvent{Type: CosmeticAdded, Category: category, ID: itemID, New: item})
+		case !reflect.DeepEqual(old, item):
+			events = append(events, CosmeticEvent{Type: CosmeticChanged, Category: category, ID: itemID, Old: old, New: item})
+		}
+	}
+
+	for _, item := range oldItems {
+		if _, ok := previous[id(item)]; ok {
+			events = append(events, CosmeticEvent{Type: CosmeticRemoved, Category: category, ID: id(item), Old: item})
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,177 @@
+package fortniteapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCosmeticsState struct {
+	hashes    NewCosmeticsHashes
+	br        []BRCosmetic
+	brFetches int
+}
+
+type fakeCosmeticsServer struct {
+	*fakeServer[fakeCosmeticsState]
+}
+
+func newFakeCosmeticsServer() fakeCosmeticsServer {
+	return fakeCosmeticsServer{&fakeServer[fakeCosmeticsState]{
+		serve: func(t *testing.T, w http.ResponseWriter, r *http.Request, state *fakeCosmeticsState) {
+			switch r.URL.Path {
+			case "/v2/cosmetics/new":
+				writeTestData(t, w, NewCosmeticsResponse{Hashes: state.hashes})
+			case "/v2/cosmetics/br":
+				state.brFetches++
+				writeTestData(t, w, state.br)
+			default:
+				writeTestData(t, w, []any{})
+			}
+		},
+	}}
+}
+
+func (f fakeCosmeticsServer) update(hash string, br []BRCosmetic) {
+	f.change(func(state *fakeCosmeticsState) {
+		state.hashes.BR = hash
+		state.br = br
+	})
+}
+
+func (f fakeCosmeticsServer) fetches() int {
+	return f.get().brFetches
+}
+
+func Test_CosmeticsSyncer_Sync(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeCosmeticsServer()
+	server.update("h1", []BRCosmetic{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}})
+
+	client := newTestClient(t, server.handle(t))
+	syncer := NewCosmeticsSyncer(client, CosmeticsSyncerOptions{})
+
+	events, err := syncer.Sync(testCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, CosmeticAdded, events[0].Type)
+	assert.Equal(t, CosmeticCategoryBR, events[0].Category)
+
+	events, err = syncer.Sync(testCtx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, 1, server.fetches())
+
+	server.update("h2", []BRCosmetic{{ID: "a", Name: "A2"}, {ID: "c", Name: "C"}})
+
+	events, err = syncer.Sync(testCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, CosmeticEvent{Type: CosmeticChanged, Category: CosmeticCategoryBR, ID: "a", Old: BRCosmetic{ID: "a", Name: "A"}, New: BRCosmetic{ID: "a", Name: "A2"}}, events[0])
+	assert.Equal(t, CosmeticAdded, events[1].Type)
+	assert.Equal(t, "c", events[1].ID)
+	assert.Equal(t, CosmeticRemoved, events[2].Type)
+	assert.Equal(t, "b", events[2].ID)
+
+	snapshot, hashes := syncer.Snapshot()
+	assert.Len(t, snapshot.BR, 2)
+	assert.Equal(t, "h2", hashes.BR)
+}
+
+func Test_CosmeticsSyncer_Load(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeCosmeticsServer()
+	server.update("h1", []BRCosmetic{{ID: "a"}})
+
+	client := newTestClient(t, server.handle(t))
+	syncer := NewCosmeticsSyncer(client, CosmeticsSyncerOptions{})
+	syncer.Load(AllCosmeticsResponse{BR: []BRCosmetic{{ID: "a"}}}, NewCosmeticsHashes{BR: "h1"})
+
+	events, err := syncer.Sync(testCtx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, 0, server.fetches())
+}
+
+func Test_CosmeticsSyncer_Run(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	server := newFakeCosmeticsServer()
+	server.update("h1", []BRCosmetic{{ID: "a"}})
+
+	client := newTestClient(t, server.handle(t))
+	syncer := NewCosmeticsSyncer(client, CosmeticsSyncerOptions{Interval: time.Minute, Clock: clock})
+
+	ctx, cancel := context.WithCancel(testCtx)
+	defer cancel()
+
+	events := make(chan CosmeticEvent, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- syncer.Run(ctx, func(event CosmeticEvent) { events <- event })
+	}()
+
+	assert.Equal(t, time.Minute, clock.advance(t, func() {
+		assert.Equal(t, "a", (<-events).ID)
+		server.update("h2", []BRCosmetic{{ID: "a"}, {ID: "b"}})
+	}))
+
+	clock.advance(t, nil)
+	assert.Equal(t, CosmeticEvent{Type: CosmeticAdded, Category: CosmeticCategoryBR, ID: "b", New: BRCosmetic{ID: "b"}}, <-events)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func Test_CosmeticsSyncer_SnapshotDuringSync(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeCosmeticsServer()
+	server.update("h1", []BRCosmetic{{ID: "a"}})
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/cosmetics/br" {
+			close(started)
+			<-release
+		}
+
+		server.handle(t)(w, r)
+	})
+
+	syncer := NewCosmeticsSyncer(client, CosmeticsSyncerOptions{})
+	syncer.Load(AllCosmeticsResponse{BR: []BRCosmetic{{ID: "old"}}}, NewCosmeticsHashes{BR: "h0"})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := syncer.Sync(testCtx)
+		done <- err
+	}()
+
+	<-started
+
+	// The fetch is still running, so the previous state is returned.
+	snapshot, hashes := syncer.Snapshot()
+	assert.Equal(t, "old", snapshot.BR[0].ID)
+	assert.Equal(t, "h0", hashes.BR)
+
+	close(release)
+	require.NoError(t, <-done)
+
+	snapshot, hashes = syncer.Snapshot()
+	assert.Equal(t, "a", snapshot.BR[0].ID)
+	assert.Equal(t, "h1", hashes.BR)
+}
@@ -0,0 +1,97 @@
+package fortniteapi
+
+import (
+	"slices"
+	"time"
+)
+
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	time.DateOnly,
+}
+
+func parseAPITime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range apiTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// ShopHistory lists the days a cosmetic was in the shop. The API only
+// includes it with FlagIncludeShopHistory. Invalid dates are skipped by
+// every method.
+type ShopHistory []string
+
+// Times returns the parsed dates, oldest first.
+func (h ShopHistory) Times() []time.Time {
+	times := make([]time.Time, 0, len(h))
+	for _, value := range h {
+		if parsed, ok := parseAPITime(value); ok {
+			times = append(times, parsed)
+		}
+	}
+
+	slices.SortFunc(times, time.Time.Compare)
+	return times
+}
+
+// FirstSeen returns the first day in the shop.
+func (h ShopHistory) FirstSeen() (time.Time, bool) {
+	times := h.Times()
+	if len(times) == 0 {
+		return time.Time{}, false
+	}
+
+	return times[0], true
+}
+
+// LastSeenInShop returns the latest day in the shop.
+func (h ShopHistory) LastSeenInShop() (time.Time, bool) {
+	times := h.Times()
+	if len(times) == 0 {
+		return time.Time{}, false
+	}
+
+	return times[len(times)-1], true
+}
+
+func (h ShopHistory) TimesInShop() int {
+	return len(h.Times())
+}
+
+// DaysSinceLastAppearance returns the number of shop days between the
+// latest appearance and now, which is 0 while the cosmetic is in the shop.
+func (h ShopHistory) DaysSinceLastAppearance(now time.Time) (int, bool) {
+	last, ok := h.LastSeenInShop()
+	if !ok {
+		return 0, false
+	}
+
+	return daysBetween(last, now), true
+}
+
+// daysBetween returns the number of UTC days from a to b.
+func daysBetween(a, b time.Time) int {
+	from := a.UTC().Truncate(shopResetInterval)
+	to := b.UTC().Truncate(shopResetInterval)
+
+	return int(to.Sub(from) / shopResetInterval)
+}
+
+// AddedTime reports false when the date is missing or invalid.
+func (p *Playlist) AddedTime() (time.Time, bool) {
+	return parseAPITime(p.Added)
+}
+
+// LastModifiedTime reports false when the date is missing or invalid.
+func (d BRStatsData) LastModifiedTime() (time.Time, bool) {
+	return parseAPITime(d.LastModified)
+}
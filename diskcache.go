@@ -0,0 +1,409 @@
+package fortniteapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	diskCacheBodySuffix = ".json.gz"
+	diskCacheMetaSuffix = ".meta.json"
+	tempFilePrefix      = ".tmp-"
+
+	// staleTempFileAge is how old a temporary file must be before eviction
+	// removes it as left behind by a crashed writer.
+	staleTempFileAge = time.Hour
+)
+
+var ErrCacheEntryTooLarge = errors.New("cache entry is larger than the maximum cache size")
+
+type DiskCacheOptions struct {
+	// MaxSize is the maximum total size in bytes of the compressed bodies.
+	// The least recently used entries are evicted first, and entries larger
+	// than MaxSize are rejected with ErrCacheEntryTooLarge. Zero means
+	// unlimited.
+	MaxSize int64
+}
+
+// DiskCache stores each entry as a gzip-compressed body next to a JSON
+// metadata file. Files are written to a temporary name and renamed into
+// place, so several processes can share the same directory.
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	// size is a running total of the compressed bodies, kept once the
+	// directory has been scanned. Entries written by other processes are
+	// counted at the next scan, which happens when size goes over maxSize.
+	mu        sync.Mutex
+	size      int64
+	sizeKnown bool
+}
+
+type diskCacheMeta struct {
+	Key          string    `json:"key"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	Size         int64     `json:"size"`
+	Checksum     string    `json:"checksum"`
+}
+
+func NewDiskCache(dir string, options DiskCacheOptions) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &DiskCache{
+		dir:     dir,
+		maxSize: options.MaxSize,
+	}, nil
+}
+
+func (d *DiskCache) Get(_ context.Context, key string) (*CacheEntry, error) {
+	name := diskCacheName(key)
+
+	meta, err := d.readMeta(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.Key != key {
+		return nil, ErrCacheMiss
+	}
+
+	compressed, err := os.ReadFile(d.path(name + diskCacheBodySuffix))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrCacheMiss
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read cache body: %w", err)
+	}
+
+	// Another process may have replaced the body after we read the metadata.
+	if checksum(compressed) != meta.Checksum {
+		return nil, ErrCacheMiss
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress cache body: %w", err)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress cache body: %w", err)
+	}
+
+	now := time.Now()
+	os.Chtimes(d.path(name+diskCacheMetaSuffix), now, now) //nolint:errcheck
+
+	return &CacheEntry{
+		Body:         body,
+		ETag:         meta.ETag,
+		LastModified: meta.LastModified,
+		StoredAt:     meta.StoredAt,
+		ExpiresAt:    meta.ExpiresAt,
+	}, nil
+}
+
+func (d *DiskCache) Set(_ context.Context, key string, entry *CacheEntry) error {
+	name := diskCacheName(key)
+
+	var compressed bytes.Buffer
+
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(entry.Body); err != nil {
+		return fmt.Errorf("failed to compress cache body: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to compress cache body: %w", err)
+	}
+
+	if d.maxSize > 0 && int64(compressed.Len()) > d.maxSize {
+		return ErrCacheEntryTooLarge
+	}
+
+	meta, err := json.Marshal(diskCacheMeta{
+		Key:          key,
+		ETag:         entry.ETag,
+		LastModified: entry.LastModified,
+		StoredAt:     entry.StoredAt,
+		ExpiresAt:    entry.ExpiresAt,
+		Size:         int64(compressed.Len()),
+		Checksum:     checksum(compressed.Bytes()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache metadata: %w", err)
+	}
+
+	var previousSize int64
+	if d.maxSize > 0 {
+		if previous, err := d.readMeta(name); err == nil {
+			previousSize = previous.Size
+		}
+	}
+
+	if err := d.writeAtomic(name+diskCacheBodySuffix, compressed.Bytes()); err != nil {
+		return err
+	}
+
+	if err := d.writeAtomic(name+diskCacheMetaSuffix, meta); err != nil {
+		return err
+	}
+
+	return d.grow(name, int64(compressed.Len())-previousSize)
+}
+
+func (d *DiskCache) Delete(_ context.Context, key string) error {
+	name := diskCacheName(key)
+
+	var size int64
+	if meta, err := d.readMeta(name); err == nil {
+		size = meta.Size
+	}
+
+	for _, suffix := range []string{diskCacheMetaSuffix, diskCacheBodySuffix} {
+		if err := os.Remove(d.path(name + suffix)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete cache entry: %w", err)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.sizeKnown {
+		d.size -= size
+	}
+
+	return nil
+}
+
+// Size returns the total size in bytes of the compressed bodies.
+func (d *DiskCache) Size() (int64, error) {
+	entries, err := d.entries()
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, entry := range entries {
+		size += entry.size
+	}
+
+	return size, nil
+}
+
+func (d *DiskCache) readMeta(name string) (*diskCacheMeta, error) {
+	data, err := os.ReadFile(d.path(name + diskCacheMetaSuffix))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrCacheMiss
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read cache metadata: %w", err)
+	}
+
+	var meta diskCacheMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, ErrCacheMiss
+	}
+
+	return &meta, nil
+}
+
+func (d *DiskCache) writeAtomic(name string, data []byte) error {
+	if err := writeFileAtomic(d.path(name), data); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), tempFilePrefix+"*")
+	if err != nil {
+		return err
+	}
+
+	tempPath := file.Name()
+	defer os.Remove(tempPath) //nolint:errcheck
+
+	if _, err := file.Write(data); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close() //nolint:errcheck
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, path)
+}
+
+type diskCacheFile struct {
+	name     string
+	size     int64
+	accessed time.Time
+}
+
+func (d *DiskCache) entries() ([]diskCacheFile, error) {
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var entries []diskCacheFile
+
+	for _, dirEntry := range dirEntries {
+		name, ok := strings.CutSuffix(dirEntry.Name(), diskCacheMetaSuffix)
+		if !ok {
+			continue
+		}
+
+		meta, err := d.readMeta(name)
+		if err != nil {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, diskCacheFile{
+			name:     name,
+			size:     meta.Size,
+			accessed: info.ModTime(),
+		})
+	}
+
+	return entries, nil
+}
+
+// grow adds delta to the running size and only scans the directory for
+// entries to evict when the total goes over MaxSize.
+func (d *DiskCache) grow(keep string, delta int64) error {
+	if d.maxSize <= 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.sizeKnown {
+		d.size += delta
+		if d.size <= d.maxSize {
+			return nil
+		}
+	}
+
+	size, err := d.evict(keep)
+	if err != nil {
+		d.sizeKnown = false
+		return err
+	}
+
+	d.size, d.sizeKnown = size, true
+	return nil
+}
+
+// evict removes the least recently used entries until the cache fits in
+// MaxSize, never the entry named keep, and removes stale temporary files.
+// It returns the size left in the directory.
+func (d *DiskCache) evict(keep string) (int64, error) {
+	if err := d.removeStaleTempFiles(); err != nil {
+		return 0, err
+	}
+
+	entries, err := d.entries()
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, entry := range entries {
+		size += entry.size
+	}
+
+	slices.SortFunc(entries, func(a, b diskCacheFile) int {
+		return a.accessed.Compare(b.accessed)
+	})
+
+	for _, entry := range entries {
+		if size <= d.maxSize {
+			break
+		}
+
+		if entry.name == keep {
+			continue
+		}
+
+		for _, suffix := range []string{diskCacheMetaSuffix, diskCacheBodySuffix} {
+			if err := os.Remove(d.path(entry.name + suffix)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return 0, fmt.Errorf("failed to evict cache entry: %w", err)
+			}
+		}
+
+		size -= entry.size
+	}
+
+	return size, nil
+}
+
+func (d *DiskCache) removeStaleTempFiles() error {
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	for _, dirEntry := range dirEntries {
+		if !strings.HasPrefix(dirEntry.Name(), tempFilePrefix) {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil || time.Since(info.ModTime()) < staleTempFileAge {
+			continue
+		}
+
+		if err := os.Remove(d.path(dirEntry.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove temporary cache file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DiskCache) path(name string) string {
+	return filepath.Join(d.dir, name)
+}
+
+func diskCacheName(key string) string {
+	return checksum([]byte(key))
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,195 @@
+package fortniteapi
+
+import (
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DiskCache_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(t.TempDir(), DiskCacheOptions{})
+	require.NoError(t, err)
+
+	_, err = cache.Get(testCtx, "missing")
+	require.ErrorIs(t, err, ErrCacheMiss)
+
+	entry := &CacheEntry{
+		Body:      []byte(`{"status":200,"data":{}}`),
+		ETag:      `"abc"`,
+		StoredAt:  time.Now().UTC().Truncate(time.Second),
+		ExpiresAt: time.Now().UTC().Add(time.Hour).Truncate(time.Second),
+	}
+	require.NoError(t, cache.Set(testCtx, "GET /v2/cosmetics", entry))
+
+	got, err := cache.Get(testCtx, "GET /v2/cosmetics")
+	require.NoError(t, err)
+	assert.Equal(t, entry.Body, got.Body)
+	assert.Equal(t, entry.ETag, got.ETag)
+	assert.True(t, entry.ExpiresAt.Equal(got.ExpiresAt))
+
+	require.NoError(t, cache.Delete(testCtx, "GET /v2/cosmetics"))
+
+	_, err = cache.Get(testCtx, "GET /v2/cosmetics")
+	require.ErrorIs(t, err, ErrCacheMiss)
+}
+
+func Test_DiskCache_SharedDirectory(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	first, err := NewDiskCache(dir, DiskCacheOptions{})
+	require.NoError(t, err)
+
+	second, err := NewDiskCache(dir, DiskCacheOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, first.Set(testCtx, "key", &CacheEntry{Body: []byte("data")}))
+
+	got, err := second.Get(testCtx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), got.Body)
+}
+
+func Test_DiskCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(t.TempDir(), DiskCacheOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set(testCtx, "first", &CacheEntry{Body: []byte(strings.Repeat("a", 100))}))
+
+	size, err := cache.Size()
+	require.NoError(t, err)
+	cache.maxSize = size + 1
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, cache.Set(testCtx, "second", &CacheEntry{Body: []byte(strings.Repeat("b", 100))}))
+
+	_, err = cache.Get(testCtx, "first")
+	require.ErrorIs(t, err, ErrCacheMiss)
+
+	_, err = cache.Get(testCtx, "second")
+	require.NoError(t, err)
+}
+
+func Test_DiskCache_KeepsRunningSize(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(t.TempDir(), DiskCacheOptions{MaxSize: 1 << 20})
+	require.NoError(t, err)
+
+	assertSize := func() {
+		t.Helper()
+
+		size, err := cache.Size()
+		require.NoError(t, err)
+		assert.True(t, cache.sizeKnown)
+		assert.Equal(t, size, cache.size)
+	}
+
+	require.NoError(t, cache.Set(testCtx, "first", &CacheEntry{Body: []byte(strings.Repeat("a", 100))}))
+	assertSize()
+
+	require.NoError(t, cache.Set(testCtx, "second", &CacheEntry{Body: []byte("b")}))
+	require.NoError(t, cache.Set(testCtx, "first", &CacheEntry{Body: []byte("a")}))
+	assertSize()
+
+	require.NoError(t, cache.Delete(testCtx, "second"))
+	assertSize()
+}
+
+func Test_DiskCache_RejectsEntriesLargerThanMaxSize(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(t.TempDir(), DiskCacheOptions{MaxSize: 64})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set(testCtx, "small", &CacheEntry{Body: []byte("small")}))
+
+	// Random bytes don't compress below MaxSize.
+	large := make([]byte, 256)
+	_, err = rand.Read(large)
+	require.NoError(t, err)
+
+	err = cache.Set(testCtx, "large", &CacheEntry{Body: large})
+	require.ErrorIs(t, err, ErrCacheEntryTooLarge)
+
+	_, err = cache.Get(testCtx, "small")
+	require.NoError(t, err)
+}
+
+func Test_DiskCache_KeepsNewEntryAndRemovesStaleTempFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(dir, DiskCacheOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set(testCtx, "first", &CacheEntry{Body: []byte(strings.Repeat("a", 100))}))
+
+	size, err := cache.Size()
+	require.NoError(t, err)
+	cache.maxSize = size
+
+	stale := filepath.Join(dir, tempFilePrefix+"stale")
+	fresh := filepath.Join(dir, tempFilePrefix+"fresh")
+	require.NoError(t, os.WriteFile(stale, []byte("partial"), 0o600))
+	require.NoError(t, os.WriteFile(fresh, []byte("partial"), 0o600))
+
+	old := time.Now().Add(-2 * staleTempFileAge)
+	require.NoError(t, os.Chtimes(stale, old, old))
+
+	// The new entry is as large as the cache, so only the old one goes.
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, cache.Set(testCtx, "second", &CacheEntry{Body: []byte(strings.Repeat("b", 100))}))
+
+	_, err = cache.Get(testCtx, "second")
+	require.NoError(t, err)
+
+	_, err = cache.Get(testCtx, "first")
+	require.ErrorIs(t, err, ErrCacheMiss)
+
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, fresh)
+}
+
+func Test_DiskCache_ServesStaleWhenOffline(t *testing.T) {
+	t.Parallel()
+
+	cache, err := NewDiskCache(t.TempDir(), DiskCacheOptions{})
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		writeTestData(t, w, AllCosmeticsResponse{BR: []BRCosmetic{{ID: testCosmeticID1}}})
+	}))
+
+	options := CacheOptions{DefaultTTL: time.Nanosecond, ServeStaleOnError: true}
+
+	online := NewClient(LanguageEnglish, "", WithBaseURL(server.URL), WithCache(cache, options))
+	_, err = online.GetAllCosmetics(testCtx, nil)
+	require.NoError(t, err)
+
+	server.Close()
+
+	offline := NewClient(LanguageEnglish, "", WithBaseURL(server.URL), WithCache(cache, options))
+	resp, err := offline.GetAllCosmetics(testCtx, nil)
+	require.NoError(t, err)
+	require.Len(t, resp.BR, 1)
+	assert.Equal(t, testCosmeticID1, resp.BR[0].ID)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,412 @@
+package fortniteapi
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultRarityBoosts ranks rarer cosmetics slightly higher among equally
+// good matches. Keys are lowercase rarity values.
+var DefaultRarityBoosts = map[string]float64{
+	"uncommon":  0.01,
+	"rare":      0.02,
+	"epic":      0.03,
+	"legendary": 0.04,
+	"mythic":    0.05,
+	"exotic":    0.05,
+}
+
+// seriesBoost applies to cosmetics of a series, such as Icon or Marvel,
+// which have no rarity of their own.
+const seriesBoost = 0.03
+
+type FuzzyIndexOptions struct {
+	// Language of the indexed names, used for case folding.
+	//
+	// Default: English
+	Language Language
+
+	// Default: DefaultRarityBoosts
+	RarityBoosts map[string]float64
+
+	// RecencyBoost is the boost of a cosmetic added today. It decreases
+	// linearly to zero over RecencyWindow. A negative value disables it.
+	//
+	// Default: 0.05
+	RecencyBoost float64
+
+	// Default: 365 days
+	RecencyWindow time.Duration
+
+	Clock Clock
+}
+
+type FuzzySearchParams struct {
+	// Default: 10
+	Limit int
+
+	// MinScore drops matches below this score, between 0 and 1 before
+	// boosts.
+	//
+	// Default: 0.5
+	MinScore float64
+
+	// Default: every category
+	Categories []CosmeticCategory
+}
+
+type FuzzyMatch struct {
+	Category CosmeticCategory
+	ID       string
+	Name     string
+	Score    float64
+	Cosmetic Cosmetic
+}
+
+type fuzzyEntry struct {
+	cosmetic Cosmetic
+	boost    float64
+
+	normalized []rune
+	words      [][]rune
+	grams      []string
+}
+
+// FuzzyIndex ranks cosmetics of every category by how closely their names
+// match a query, tolerating typos. Names are compared rune by rune, so
+// queries in any language, including Chinese, Japanese and Korean, work.
+type FuzzyIndex struct {
+	options FuzzyIndexOptions
+	entries []fuzzyEntry
+	grams   map[string][]int
+}
+
+func NewFuzzyIndex(cosmetics *AllCosmeticsResponse, options FuzzyIndexOptions) *FuzzyIndex {
+	if options.Language == "" {
+		options.Language = LanguageEnglish
+	}
+
+	if options.RarityBoosts == nil {
+		options.RarityBoosts = DefaultRarityBoosts
+	}
+
+	if options.RecencyBoost == 0 {
+		options.RecencyBoost = 0.05
+	}
+
+	if options.RecencyWindow <= 0 {
+		options.RecencyWindow = 365 * 24 * time.Hour
+	}
+
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	index := &FuzzyIndex{
+		options: options,
+		grams:   make(map[string][]int),
+	}
+
+	now := options.Clock.Now()
+
+	for cosmetic := range cosmetics.Iter() {
+		entry := fuzzyEntry{
+			cosmetic:   cosmetic,
+			normalized: normalizeFuzzy(options.Language, cosmetic.DisplayName()),
+		}
+
+		if entry.normalized == nil {
+			continue
+		}
+
+		entry.words = splitFuzzyWords(entry.normalized)
+		entry.grams = uniqueGrams(entry.normalized)
+		entry.boost = index.boost(cosmetic, now)
+
+		position := len(index.entries)
+		index.entries = append(index.entries, entry)
+
+		// Single runes are indexed too, so one-rune queries find candidates.
+		// Clip keeps the appends off the entry's bigrams.
+		grams := slices.Clip(entry.grams)
+		for _, r := range entry.normalized {
+			if gram := string(r); r != ' ' && !slices.Contains(grams, gram) {
+				grams = append(grams, gram)
+			}
+		}
+
+		for _, gram := range grams {
+			index.grams[gram] = append(index.grams[gram], position)
+		}
+	}
+
+	return index
+}
+
+func (x *FuzzyIndex) boost(cosmetic Cosmetic, now time.Time) float64 {
+	var boost float64
+
+	if cosmetic.SeriesValue() != "" {
+		boost += seriesBoost
+	} else {
+		boost += x.options.RarityBoosts[strings.ToLower(cosmetic.RarityValue())]
+	}
+
+	if x.options.RecencyBoost > 0 {
+		if addedAt, ok := cosmetic.AddedTime(); ok {
+			age := now.Sub(addedAt)
+			if age >= 0 && age < x.options.RecencyWindow {
+				boost += x.options.RecencyBoost * (1 - float64(age)/float64(x.options.RecencyWindow))
+			}
+		}
+	}
+
+	return boost
+}
+
+func (x *FuzzyIndex) Len() int {
+	return len(x.entries)
+}
+
+// Search returns the best matches for query, highest score first.
+func (x *FuzzyIndex) Search(query string, params *FuzzySearchParams) []FuzzyMatch {
+	if params == nil {
+		params = &FuzzySearchParams{}
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	minScore := params.MinScore
+	if minScore <= 0 {
+		minScore = 0.5
+	}
+
+	normalized := normalizeFuzzy(x.options.Language, query)
+	if normalized == nil {
+		return nil
+	}
+
+	words := splitFuzzyWords(normalized)
+	queryGrams := uniqueGrams(normalized)
+
+	var matches []FuzzyMatch
+
+	for _, position := range x.candidates(queryGrams) {
+		entry := &x.entries[position]
+
+		if len(params.Categories) > 0 && !slices.Contains(params.Categories, entry.cosmetic.Kind()) {
+			continue
+		}
+
+		score := fuzzyScore(normalized, words, queryGrams, entry)
+		if score < minScore {
+			continue
+		}
+
+		matches = append(matches, FuzzyMatch{
+			Category: entry.cosmetic.Kind(),
+			ID:       entry.cosmetic.ItemID(),
+			Name:     entry.cosmetic.DisplayName(),
+			Score:    score * (1 + entry.boost),
+			Cosmetic: entry.cosmetic,
+		})
+	}
+
+	slices.SortStableFunc(matches, func(a, b FuzzyMatch) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+// candidates returns the entries sharing at least one n-gram with the
+// query, in index order.
+func (x *FuzzyIndex) candidates(queryGrams []string) []int {
+	seen := make(map[int]struct{})
+
+	for _, gram := range queryGrams {
+		for _, position := range x.grams[gram] {
+			seen[position] = struct{}{}
+		}
+	}
+
+	positions := make([]int, 0, len(seen))
+	for position := range seen {
+		positions = append(positions, position)
+	}
+
+	slices.Sort(positions)
+	return positions
+}
+
+// fuzzyScore returns a similarity between 0 and 1. It takes the best of a
+// whole-name edit distance, a prefix comparison for partially typed names,
+// a word by word comparison and the bigram overlap, which carries scripts
+// without spaces.
+func fuzzyScore(query []rune, queryWords [][]rune, queryGrams []string, entry *fuzzyEntry) float64 {
+	name := entry.normalized
+
+	if slices.Equal(query, name) {
+		return 1
+	}
+
+	score := similarity(query, name)
+
+	if len(query) < len(name) {
+		prefix := similarity(query, name[:len(query)])
+		if prefix == 1 {
+			prefix = 0.9 + 0.1*float64(len(query))/float64(len(name))
+		} else {
+			prefix *= 0.9
+		}
+
+		score = max(score, prefix)
+	}
+
+	var wordScore float64
+	for _, queryWord := range queryWords {
+		var best float64
+		for _, word := range entry.words {
+			best = max(best, similarity(queryWord, word))
+		}
+
+		wordScore += best
+	}
+
+	score = max(score, 0.9*wordScore/float64(len(queryWords)))
+
+	return max(score, diceCoefficient(queryGrams, entry.grams))
+}
+
+func similarity(a, b []rune) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := range a {
+		current[0] = i + 1
+
+		for j := range b {
+			cost := 1
+			if a[i] == b[j] {
+				cost = 0
+			}
+
+			current[j+1] = min(previous[j+1]+1, current[j]+1, previous[j]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func diceCoefficient(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	var shared int
+	for _, gram := range a {
+		if slices.Contains(b, gram) {
+			shared++
+		}
+	}
+
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+// normalizeFuzzy folds case, drops apostrophes and turns every other rune
+// that isn't a letter or digit into a single space.
+func normalizeFuzzy(language Language, s string) []rune {
+	var (
+		normalized []rune
+		space      bool
+	)
+
+	for _, r := range foldCase(language, s) {
+		switch {
+		case r == '\'' || r == '’':
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r):
+			if space && len(normalized) > 0 {
+				normalized = append(normalized, ' ')
+			}
+
+			normalized = append(normalized, r)
+			space = false
+		default:
+			space = true
+		}
+	}
+
+	return normalized
+}
+
+func splitFuzzyWords(normalized []rune) [][]rune {
+	var words [][]rune
+
+	start := 0
+	for i, r := range normalized {
+		if r == ' ' {
+			words = append(words, normalized[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(words, normalized[start:])
+}
+
+// uniqueGrams returns the distinct rune bigrams of s, ignoring spaces. A
+// single rune is its own gram.
+func uniqueGrams(s []rune) []string {
+	compact := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r != ' ' {
+			compact = append(compact, r)
+		}
+	}
+
+	if len(compact) < 2 {
+		if len(compact) == 0 {
+			return nil
+		}
+
+		return []string{string(compact)}
+	}
+
+	grams := make([]string, 0, len(compact)-1)
+	for i := range len(compact) - 1 {
+		gram := string(compact[i : i+2])
+		if !slices.Contains(grams, gram) {
+			grams = append(grams, gram)
+		}
+	}
+
+	return grams
+}
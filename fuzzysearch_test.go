@@ -0,0 +1,79 @@
+package fortniteapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFuzzyCosmetics() *AllCosmeticsResponse {
+	return &AllCosmeticsResponse{
+		BR: []BRCosmetic{
+			{ID: "CID_001", Name: "Renegade Raider", Rarity: BRCosmeticRarity{Value: "rare"}, Added: "2017-10-26T00:00:00Z"},
+			{ID: "CID_002", Name: "Peely", Rarity: BRCosmeticRarity{Value: "epic"}, Added: "2019-03-01T00:00:00Z"},
+			{ID: "CID_003", Name: "Peel", Rarity: BRCosmeticRarity{Value: "uncommon"}, Added: "2019-03-01T00:00:00Z"},
+			{ID: "CID_004", Name: "ドラゴン", Added: "2024-12-01T00:00:00Z"},
+		},
+		Tracks: []Track{{ID: "track1", Title: "Renegade", Added: "2024-01-01T00:00:00Z"}},
+		Cars:   []Car{{ID: "car1", Name: "Peely Mobile"}},
+	}
+}
+
+func Test_FuzzyIndex_Search(t *testing.T) {
+	t.Parallel()
+
+	index := NewFuzzyIndex(testFuzzyCosmetics(), FuzzyIndexOptions{
+		Clock: newFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+
+	matches := index.Search("peelly", nil)
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "CID_002", matches[0].ID)
+	assert.IsType(t, &BRCosmetic{}, matches[0].Cosmetic)
+
+	matches = index.Search("renagade raider", nil)
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "CID_001", matches[0].ID)
+
+	matches = index.Search("renegade", &FuzzySearchParams{Categories: []CosmeticCategory{CosmeticCategoryTracks}})
+	require.Len(t, matches, 1)
+	assert.Equal(t, CosmeticCategoryTracks, matches[0].Category)
+	assert.Equal(t, "Renegade", matches[0].Name)
+
+	matches = index.Search("ドラゴ", nil)
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "CID_004", matches[0].ID)
+
+	matches = index.Search("ド", &FuzzySearchParams{MinScore: 0.1})
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "CID_004", matches[0].ID)
+
+	assert.Len(t, index.Search("peel", &FuzzySearchParams{Limit: 2}), 2)
+	assert.Empty(t, index.Search("zzzz", nil))
+}
+
+func Test_FuzzyIndex_RarityBreaksTies(t *testing.T) {
+	t.Parallel()
+
+	index := NewFuzzyIndex(&AllCosmeticsResponse{
+		BR: []BRCosmetic{
+			{ID: "common", Name: "Raider", Rarity: BRCosmeticRarity{Value: "common"}},
+			{ID: "legendary", Name: "Raider", Rarity: BRCosmeticRarity{Value: "legendary"}},
+		},
+	}, FuzzyIndexOptions{RecencyBoost: -1})
+
+	matches := index.Search("raider", nil)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "legendary", matches[0].ID)
+	assert.Greater(t, matches[0].Score, matches[1].Score)
+}
+
+func Test_levenshtein(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, levenshtein([]rune("peely"), []rune("peelly")))
+	assert.Equal(t, 3, levenshtein([]rune("kitten"), []rune("sitting")))
+	assert.Equal(t, 1, levenshtein([]rune("ドラゴン"), []rune("ドラゴ")))
+}
@@ -0,0 +1,60 @@
+package fortniteapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageFetcher returns the bytes of the image at a URL.
+type ImageFetcher interface {
+	FetchImage(ctx context.Context, url string) ([]byte, error)
+}
+
+type ImageFetcherFunc func(ctx context.Context, url string) ([]byte, error)
+
+func (f ImageFetcherFunc) FetchImage(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+// FetchImage downloads an image with the client's retry policy and rate
+// limiter. The API key is only sent to the scheme and host of the base URL,
+// so it never goes to other hosts or over a downgraded connection.
+func (c *Client) FetchImage(ctx context.Context, imageURL string) ([]byte, error) {
+	response, err := c.doImage(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close() //nolint:errcheck
+
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, newAPIError(http.MethodGet, response.Request.URL.Path, response, raw)
+	}
+
+	return raw, nil
+}
+
+// doImage sends a request for a URL that may live outside the API.
+func (c *Client) doImage(ctx context.Context, method, imageURL string, header http.Header) (*http.Response, error) {
+	target, err := url.Parse(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	authorize := target.Scheme == base.Scheme && target.Host == base.Host
+
+	return c.send(ctx, method, imageURL, nil, header, authorize)
+}
@@ -0,0 +1,51 @@
+package fortniteapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_FetchImage(t *testing.T) {
+	t.Parallel()
+
+	var authorization []string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+
+		if r.URL.Path == "/images/missing.png" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte("image")) //nolint:errcheck
+	})
+
+	client.apiKey = "key"
+
+	data, err := client.FetchImage(testCtx, client.baseURL+"/images/icon.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("image"), data)
+
+	_, err = client.FetchImage(testCtx, client.baseURL+"/images/missing.png")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	other := NewClient(LanguageEnglish, "key", WithBaseURL("https://example.invalid"))
+	other.httpClient = client.httpClient
+
+	_, err = other.FetchImage(testCtx, client.baseURL+"/images/icon.png")
+	require.NoError(t, err)
+
+	// The API host over plain HTTP when the base URL uses HTTPS.
+	secure := NewClient(LanguageEnglish, "key", WithBaseURL(strings.Replace(client.baseURL, "http://", "https://", 1)))
+	secure.httpClient = client.httpClient
+
+	_, err = secure.FetchImage(testCtx, client.baseURL+"/images/icon.png")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"key", "key", "", ""}, authorization)
+}
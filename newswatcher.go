@@ -0,0 +1,282 @@
+package fortniteapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+type NewsMode string
+
+const (
+	NewsModeBR       NewsMode = "br"
+	NewsModeSTW      NewsMode = "stw"
+	NewsModeCreative NewsMode = "creative"
+)
+
+var NewsModes = []NewsMode{NewsModeBR, NewsModeSTW, NewsModeCreative}
+
+func (r *NewsResponse) Mode(mode NewsMode) News {
+	switch mode {
+	case NewsModeBR:
+		return r.BR
+	case NewsModeSTW:
+		return r.STW
+	case NewsModeCreative:
+		return r.Creative
+	default:
+		return News{}
+	}
+}
+
+type NewsEventType string
+
+const (
+	NewsMotdAdded      NewsEventType = "motdAdded"
+	NewsMotdRemoved    NewsEventType = "motdRemoved"
+	NewsMotdUpdated    NewsEventType = "motdUpdated"
+	NewsMessageAdded   NewsEventType = "messageAdded"
+	NewsMessageRemoved NewsEventType = "messageRemoved"
+)
+
+type NewsEvent struct {
+	Type     NewsEventType
+	Language Language
+	Mode     NewsMode
+
+	// Motd and Previous are set for MOTD events. Previous holds the MOTD
+	// before an update, or the removed MOTD.
+	Motd     NewsMotd
+	Previous NewsMotd
+
+	// HiddenChanged and PriorityChanged tell which fields an update touched.
+	HiddenChanged   bool
+	PriorityChanged bool
+
+	Message NewsMessage
+}
+
+// NewsState holds the last seen news per language and mode.
+type NewsState map[Language]map[NewsMode]News
+
+type NewsStateStore interface {
+	LoadNewsState(ctx context.Context) (NewsState, error)
+	SaveNewsState(ctx context.Context, state NewsState) error
+}
+
+// FileNewsStateStore keeps the news state in a JSON file.
+type FileNewsStateStore struct {
+	Path string
+}
+
+func (f *FileNewsStateStore) LoadNewsState(_ context.Context) (NewsState, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewsState{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read news state: %w", err)
+	}
+
+	var state NewsState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode news state: %w", err)
+	}
+
+	return state, nil
+}
+
+func (f *FileNewsStateStore) SaveNewsState(_ context.Context, state NewsState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode news state: %w", err)
+	}
+
+	if err := writeFileAtomic(f.Path, data); err != nil {
+		return fmt.Errorf("failed to write news state: %w", err)
+	}
+
+	return nil
+}
+
+type NewsWatcherOptions struct {
+	// Languages to watch. Defaults to the client's language.
+	Languages []Language
+
+	// Interval is the time between two polls in Run.
+	//
+	// Default: 5 minutes
+	Interval time.Duration
+
+	// Store persists the last seen news, so a restart doesn't report
+	// every MOTD again.
+	Store NewsStateStore
+
+	Clock   Clock
+	OnError func(error)
+}
+
+type NewsWatcher struct {
+	client  *Client
+	options NewsWatcherOptions
+
+	mu     sync.Mutex
+	state  NewsState
+	loaded bool
+}
+
+func NewNewsWatcher(client *Client, options NewsWatcherOptions) *NewsWatcher {
+	if len(options.Languages) == 0 {
+		options.Languages = []Language{client.language}
+	}
+
+	if options.Interval <= 0 {
+		options.Interval = 5 * time.Minute
+	}
+
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	return &NewsWatcher{
+		client:  client,
+		options: options,
+		state:   NewsState{},
+	}
+}
+
+func (w *NewsWatcher) Poll(ctx context.Context) ([]NewsEvent, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.loaded && w.options.Store != nil {
+		state, err := w.options.Store.LoadNewsState(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if state != nil {
+			w.state = state
+		}
+	}
+
+	w.loaded = true
+
+	var (
+		events  []NewsEvent
+		changed bool
+		pollErr error
+	)
+
+	for _, language := range w.options.Languages {
+		news, err := w.client.GetNews(ctx, &NewsParams{Language: language})
+		if err != nil {
+			pollErr = err
+			break
+		}
+
+		if w.state[language] == nil {
+			w.state[language] = make(map[NewsMode]News)
+		}
+
+		for _, mode := range NewsModes {
+			current := news.Mode(mode)
+
+			previous, ok := w.state[language][mode]
+			if ok && previous.Hash == current.Hash {
+				continue
+			}
+
+			events = append(events, diffNews(language, mode, previous, current)...)
+			w.state[language][mode] = current
+			changed = true
+		}
+	}
+
+	// The languages fetched before a failure are saved too, even when only
+	// a hash changed, so the stored state matches the one in memory.
+	if w.options.Store != nil && changed {
+		if err := w.options.Store.SaveNewsState(ctx, w.state); err != nil {
+			return events, errors.Join(pollErr, err)
+		}
+	}
+
+	return events, pollErr
+}
+
+func (w *NewsWatcher) Run(ctx context.Context, handler func(NewsEvent)) error {
+	return runPolls(ctx, w.options.Clock, w.Poll, every(w.options.Interval), w.options.OnError, handler)
+}
+
+func diffNews(language Language, mode NewsMode, previous, current News) []NewsEvent {
+	var events []NewsEvent
+
+	event := func(eventType NewsEventType) NewsEvent {
+		return NewsEvent{Type: eventType, Language: language, Mode: mode}
+	}
+
+	old := make(map[string]NewsMotd, len(previous.Motds))
+	for _, motd := range previous.Motds {
+		old[motd.ID] = motd
+	}
+
+	for _, motd := range current.Motds {
+		before, ok := old[motd.ID]
+		delete(old, motd.ID)
+
+		if !ok {
+			added := event(NewsMotdAdded)
+			added.Motd = motd
+			events = append(events, added)
+
+			continue
+		}
+
+		if before != motd {
+			updated := event(NewsMotdUpdated)
+			updated.Motd = motd
+			updated.Previous = before
+			updated.HiddenChanged = before.Hidden != motd.Hidden
+			updated.PriorityChanged = before.SortingPriority != motd.SortingPriority
+			events = append(events, updated)
+		}
+	}
+
+	for _, motd := range previous.Motds {
+		if _, ok := old[motd.ID]; ok {
+			removed := event(NewsMotdRemoved)
+			removed.Previous = motd
+			events = append(events, removed)
+		}
+	}
+
+	oldMessages := make(map[NewsMessage]bool, len(previous.Messages))
+	for _, message := range previous.Messages {
+		oldMessages[message] = true
+	}
+
+	newMessages := make(map[NewsMessage]bool, len(current.Messages))
+	for _, message := range current.Messages {
+		newMessages[message] = true
+
+		if !oldMessages[message] {
+			added := event(NewsMessageAdded)
+			added.Message = message
+			events = append(events, added)
+		}
+	}
+
+	for _, message := range previous.Messages {
+		if !newMessages[message] {
+			removed := event(NewsMessageRemoved)
+			removed.Message = message
+			events = append(events, removed)
+		}
+	}
+
+	return events
+}
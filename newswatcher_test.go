@@ -0,0 +1,123 @@
+package fortniteapi
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNewsServer struct {
+	*fakeServer[map[string]NewsResponse]
+}
+
+func newFakeNewsServer() fakeNewsServer {
+	return fakeNewsServer{&fakeServer[map[string]NewsResponse]{
+		state: map[string]NewsResponse{},
+		serve: func(t *testing.T, w http.ResponseWriter, r *http.Request, news *map[string]NewsResponse) {
+			writeTestData(t, w, (*news)[r.URL.Query().Get("language")])
+		},
+	}}
+}
+
+func (f fakeNewsServer) set(language Language, news NewsResponse) {
+	f.change(func(state *map[string]NewsResponse) {
+		(*state)[string(language)] = news
+	})
+}
+
+func Test_NewsWatcher_Poll(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeNewsServer()
+	server.set(LanguageEnglish, NewsResponse{BR: News{
+		Hash:  "h1",
+		Motds: []NewsMotd{{ID: "a", Title: "A"}, {ID: "b", Title: "B", SortingPriority: 1}},
+	}})
+	server.set(LanguageGerman, NewsResponse{BR: News{
+		Hash:  "d1",
+		Motds: []NewsMotd{{ID: "a", Title: "A (de)"}},
+	}})
+
+	client := newTestClient(t, server.handle(t))
+	watcher := NewNewsWatcher(client, NewsWatcherOptions{Languages: []Language{LanguageEnglish, LanguageGerman}})
+
+	events, err := watcher.Poll(testCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, LanguageGerman, events[2].Language)
+
+	server.set(LanguageEnglish, NewsResponse{BR: News{
+		Hash:     "h2",
+		Motds:    []NewsMotd{{ID: "b", Title: "B", SortingPriority: 2, Hidden: true}, {ID: "c", Title: "C"}},
+		Messages: []NewsMessage{{Title: "Hello"}},
+	}})
+
+	events, err = watcher.Poll(testCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, NewsMotdUpdated, events[0].Type)
+	assert.Equal(t, "b", events[0].Motd.ID)
+	assert.True(t, events[0].HiddenChanged)
+	assert.True(t, events[0].PriorityChanged)
+
+	assert.Equal(t, NewsMotdAdded, events[1].Type)
+	assert.Equal(t, "c", events[1].Motd.ID)
+
+	assert.Equal(t, NewsMotdRemoved, events[2].Type)
+	assert.Equal(t, "a", events[2].Previous.ID)
+
+	assert.Equal(t, NewsMessageAdded, events[3].Type)
+	assert.Equal(t, "Hello", events[3].Message.Title)
+}
+
+func Test_NewsWatcher_PersistsState(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeNewsServer()
+	server.set(LanguageEnglish, NewsResponse{BR: News{Hash: "h1", Motds: []NewsMotd{{ID: "a"}}}})
+
+	client := newTestClient(t, server.handle(t))
+	store := &FileNewsStateStore{Path: filepath.Join(t.TempDir(), "news.json")}
+
+	first := NewNewsWatcher(client, NewsWatcherOptions{Store: store})
+	events, err := first.Poll(testCtx)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	restarted := NewNewsWatcher(client, NewsWatcherOptions{Store: store})
+	events, err = restarted.Poll(testCtx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func Test_NewsWatcher_SavesStateOnError(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeNewsServer()
+	server.set(LanguageEnglish, NewsResponse{BR: News{Hash: "h1", Motds: []NewsMotd{{ID: "a"}}}})
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("language") == string(LanguageGerman) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		server.handle(t)(w, r)
+	})
+
+	store := &FileNewsStateStore{Path: filepath.Join(t.TempDir(), "news.json")}
+
+	first := NewNewsWatcher(client, NewsWatcherOptions{Languages: []Language{LanguageEnglish, LanguageGerman}, Store: store})
+	events, err := first.Poll(testCtx)
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Len(t, events, 1)
+
+	restarted := NewNewsWatcher(client, NewsWatcherOptions{Languages: []Language{LanguageEnglish}, Store: store})
+	events, err = restarted.Poll(testCtx)
+	require.NoError(t, err)
+	assert.Empty(t, events, "the English news were saved before the German request failed")
+}
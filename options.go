@@ -0,0 +1,45 @@
+package fortniteapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Option func(*Client)
+
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets the timeout on a copy of the HTTP client, so a client
+// passed through WithHTTPClient is never modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithDefaultResponseFlags sends the given flags with every request that
+// doesn't set its own ResponseFlags.
+func WithDefaultResponseFlags(flags ...ResponseFlag) Option {
+	return func(c *Client) {
+		c.responseFlags = CombineFlags(flags...)
+	}
+}
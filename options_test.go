@@ -0,0 +1,96 @@
+package fortniteapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]Option{WithBaseURL(server.URL)}, opts...)
+	return NewClient(LanguageEnglish, "", opts...)
+}
+
+func writeTestData(t *testing.T, w http.ResponseWriter, data any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(APIResponse[any]{Status: http.StatusOK, Data: data})
+	require.NoError(t, err)
+}
+
+func Test_NewClient_Defaults(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(LanguageEnglish, "")
+	assert.Equal(t, defaultBaseURL, client.baseURL)
+	assert.Equal(t, defaultUserAgent, client.userAgent)
+	assert.NotNil(t, client.httpClient)
+}
+
+func Test_WithBaseURL(t *testing.T) {
+	t.Parallel()
+
+	paths := make(chan string, 1)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.Path
+		writeTestData(t, w, AESKeyResponse{Build: "test"})
+	})
+
+	resp, err := client.GetAESKey(testCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/v2/aes", <-paths)
+	assert.Equal(t, "test", resp.Build)
+}
+
+func Test_WithUserAgent(t *testing.T) {
+	t.Parallel()
+
+	userAgents := make(chan string, 1)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		userAgents <- r.UserAgent()
+		writeTestData(t, w, nil)
+	}, WithUserAgent("my-bot/1.0"))
+
+	_, err := client.GetBRMap(testCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "my-bot/1.0", <-userAgents)
+}
+
+func Test_WithTimeout_DoesNotModifyHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{}
+	client := NewClient(LanguageEnglish, "", WithHTTPClient(httpClient), WithTimeout(time.Second))
+
+	assert.Equal(t, time.Second, client.httpClient.Timeout)
+	assert.Zero(t, httpClient.Timeout)
+}
+
+func Test_WithDefaultResponseFlags(t *testing.T) {
+	t.Parallel()
+
+	flags := make(chan string, 2)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		flags <- r.URL.Query().Get("responseFlags")
+		writeTestData(t, w, nil)
+	}, WithDefaultResponseFlags(FlagIncludePaths, FlagIncludeShopHistory))
+
+	_, err := client.GetShop(testCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "5", <-flags)
+
+	_, err = client.GetShop(testCtx, &ShopParams{ResponseFlags: FlagIncludeGameplayTags})
+	require.NoError(t, err)
+	assert.Equal(t, "2", <-flags)
+}
@@ -0,0 +1,42 @@
+package fortniteapi
+
+import (
+	"context"
+	"time"
+)
+
+// runPolls is the loop behind the watchers' Run methods. It calls poll until
+// ctx is done, passes the events to handler and the errors to onError, and
+// sleeps for next(err) between two polls.
+func runPolls[E any](
+	ctx context.Context,
+	clock Clock,
+	poll func(context.Context) ([]E, error),
+	next func(error) time.Duration,
+	onError func(error),
+	handler func(E),
+) error {
+	for {
+		events, err := poll(ctx)
+		if err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+
+		for _, event := range events {
+			handler(event)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(next(err)):
+		}
+	}
+}
+
+// every is a next function for runPolls that always waits for interval.
+func every(interval time.Duration) func(error) time.Duration {
+	return func(error) time.Duration {
+		return interval
+	}
+}
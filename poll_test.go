@@ -0,0 +1,161 @@
+package fortniteapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waits   []time.Duration
+	waiters chan chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiters: make(chan chan time.Time, 16)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.waits = append(f.waits, d)
+
+	ch := make(chan time.Time, 1)
+	f.waiters <- ch
+
+	return ch
+}
+
+// advance waits for the next After call, runs before while the caller is
+// still sleeping, then moves the clock forward and fires the timer.
+func (f *fakeClock) advance(t *testing.T, before func()) time.Duration {
+	t.Helper()
+
+	var ch chan time.Time
+	select {
+	case ch = <-f.waiters:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the watcher to sleep")
+	}
+
+	if before != nil {
+		before()
+	}
+
+	f.mu.Lock()
+	d := f.waits[len(f.waits)-1]
+	f.now = f.now.Add(d)
+	now := f.now
+	f.mu.Unlock()
+
+	ch <- now
+	return d
+}
+
+// fakeServer serves state that tests change between requests. serve writes
+// the response while the state is locked, and defaults to writing the state.
+type fakeServer[T any] struct {
+	mu    sync.Mutex
+	state T
+	serve func(t *testing.T, w http.ResponseWriter, r *http.Request, state *T)
+}
+
+func (f *fakeServer[T]) set(state T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.state = state
+}
+
+func (f *fakeServer[T]) change(fn func(state *T)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fn(&f.state)
+}
+
+func (f *fakeServer[T]) get() T {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.state
+}
+
+func (f *fakeServer[T]) handle(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if f.serve == nil {
+			writeTestData(t, w, f.state)
+			return
+		}
+
+		f.serve(t, w, r, &f.state)
+	}
+}
+
+func Test_runPolls(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	failure := errors.New("poll failed")
+
+	var (
+		polls  int
+		errs   []error
+		events []int
+	)
+
+	poll := func(context.Context) ([]int, error) {
+		polls++
+		if polls == 2 {
+			return []int{polls}, failure
+		}
+
+		return []int{polls}, nil
+	}
+
+	next := func(err error) time.Duration {
+		if err != nil {
+			return time.Second
+		}
+
+		return time.Minute
+	}
+
+	ctx, cancel := context.WithCancel(testCtx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runPolls(ctx, clock, poll, next, func(err error) { errs = append(errs, err) }, func(event int) { events = append(events, event) })
+	}()
+
+	assert.Equal(t, time.Minute, clock.advance(t, nil))
+	assert.Equal(t, time.Second, clock.advance(t, nil))
+	clock.advance(t, cancel)
+
+	// The loop may poll once more before it sees the cancellation.
+	require.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, []int{1, 2, 3}, events[:3])
+	assert.Equal(t, []error{failure}, errs)
+}
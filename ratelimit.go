@@ -0,0 +1,255 @@
+package fortniteapi
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type EndpointGroup string
+
+const (
+	EndpointGroupDefault   EndpointGroup = "default"
+	EndpointGroupStats     EndpointGroup = "stats"
+	EndpointGroupCosmetics EndpointGroup = "cosmetics"
+	EndpointGroupShop      EndpointGroup = "shop"
+	EndpointGroupImages    EndpointGroup = "images"
+)
+
+func EndpointGroupForPath(path string) EndpointGroup {
+	switch {
+	case strings.HasPrefix(path, "/images/"):
+		return EndpointGroupImages
+	case strings.Contains(path, "/stats/"):
+		return EndpointGroupStats
+	case strings.Contains(path, "/cosmetics"):
+		return EndpointGroupCosmetics
+	case strings.Contains(path, "/shop"):
+		return EndpointGroupShop
+	default:
+		return EndpointGroupDefault
+	}
+}
+
+type RateLimiter interface {
+	// Wait blocks until a request in the group may be sent or ctx is done.
+	Wait(ctx context.Context, group EndpointGroup) error
+
+	// Update is called with every response so the limiter can adjust to
+	// the server's rate limit headers.
+	Update(group EndpointGroup, response *http.Response)
+}
+
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+type RateLimit struct {
+	// Rate is the number of requests allowed per second. Zero means unlimited.
+	Rate  float64
+	Burst int
+}
+
+type RateLimiterState struct {
+	Rate        float64
+	Burst       int
+	Tokens      float64
+	Remaining   int
+	PausedUntil time.Time
+	Waiting     int
+}
+
+type tokenBucket struct {
+	limit       RateLimit
+	tokens      float64
+	updated     time.Time
+	remaining   int
+	pausedUntil time.Time
+	waiting     int
+}
+
+// TokenBucketLimiter keeps one token bucket per endpoint group. Groups
+// without their own limit share the EndpointGroupDefault limit.
+type TokenBucketLimiter struct {
+	mu      sync.Mutex
+	limits  map[EndpointGroup]RateLimit
+	buckets map[EndpointGroup]*tokenBucket
+	now     func() time.Time
+}
+
+func NewTokenBucketLimiter(limits map[EndpointGroup]RateLimit) *TokenBucketLimiter {
+	copied := make(map[EndpointGroup]RateLimit, len(limits))
+	for group, limit := range limits {
+		copied[group] = limit
+	}
+
+	return &TokenBucketLimiter{
+		limits:  copied,
+		buckets: make(map[EndpointGroup]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+func (l *TokenBucketLimiter) Wait(ctx context.Context, group EndpointGroup) error {
+	l.mu.Lock()
+	bucket := l.bucket(group)
+	bucket.waiting++
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		bucket.waiting--
+		l.mu.Unlock()
+	}()
+
+	for {
+		l.mu.Lock()
+		delay := l.reserve(bucket)
+		l.mu.Unlock()
+
+		if delay == 0 {
+			return nil
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *TokenBucketLimiter) Update(group EndpointGroup, response *http.Response) {
+	if response == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	bucket := l.bucket(group)
+	now := l.now()
+	l.refill(bucket, now)
+
+	if limit, err := strconv.Atoi(response.Header.Get("X-RateLimit-Limit")); err == nil && limit > 0 && bucket.limit.Burst == 0 {
+		bucket.limit.Burst = limit
+	}
+
+	if remaining, err := strconv.Atoi(response.Header.Get("X-RateLimit-Remaining")); err == nil {
+		bucket.remaining = remaining
+		bucket.tokens = math.Min(bucket.tokens, float64(remaining))
+
+		if remaining <= 0 {
+			if reset, ok := parseRateLimitReset(response.Header.Get("X-RateLimit-Reset"), now); ok {
+				bucket.pausedUntil = laterTime(bucket.pausedUntil, reset)
+			}
+		}
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		if delay, ok := parseRetryAfter(response.Header.Get("Retry-After"), now); ok {
+			bucket.pausedUntil = laterTime(bucket.pausedUntil, now.Add(delay))
+		}
+	}
+}
+
+// State returns a snapshot of every bucket that has been used so far.
+func (l *TokenBucketLimiter) State() map[EndpointGroup]RateLimiterState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	state := make(map[EndpointGroup]RateLimiterState, len(l.buckets))
+
+	for group, bucket := range l.buckets {
+		l.refill(bucket, now)
+		state[group] = RateLimiterState{
+			Rate:        bucket.limit.Rate,
+			Burst:       bucket.limit.Burst,
+			Tokens:      bucket.tokens,
+			Remaining:   bucket.remaining,
+			PausedUntil: bucket.pausedUntil,
+			Waiting:     bucket.waiting,
+		}
+	}
+
+	return state
+}
+
+func (l *TokenBucketLimiter) bucket(group EndpointGroup) *tokenBucket {
+	if bucket, ok := l.buckets[group]; ok {
+		return bucket
+	}
+
+	limit, ok := l.limits[group]
+	if !ok {
+		limit = l.limits[EndpointGroupDefault]
+	}
+
+	bucket := &tokenBucket{
+		limit:     limit,
+		tokens:    float64(max(limit.Burst, 1)),
+		updated:   l.now(),
+		remaining: -1,
+	}
+
+	l.buckets[group] = bucket
+	return bucket
+}
+
+func (l *TokenBucketLimiter) refill(bucket *tokenBucket, now time.Time) {
+	elapsed := now.Sub(bucket.updated).Seconds()
+	bucket.updated = now
+
+	if bucket.limit.Rate <= 0 || elapsed <= 0 {
+		return
+	}
+
+	bucket.tokens = math.Min(bucket.tokens+elapsed*bucket.limit.Rate, float64(max(bucket.limit.Burst, 1)))
+}
+
+func (l *TokenBucketLimiter) reserve(bucket *tokenBucket) time.Duration {
+	now := l.now()
+	if now.Before(bucket.pausedUntil) {
+		return bucket.pausedUntil.Sub(now)
+	}
+
+	if bucket.limit.Rate <= 0 {
+		return 0
+	}
+
+	l.refill(bucket, now)
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - bucket.tokens) / bucket.limit.Rate * float64(time.Second))
+}
+
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// Large values are Unix timestamps, small ones are seconds until reset.
+	if reset > 1_000_000_000 {
+		return time.Unix(reset, 0), true
+	}
+
+	return now.Add(time.Duration(reset) * time.Second), true
+}
+
+func laterTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
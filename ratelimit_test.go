@@ -0,0 +1,108 @@
+package fortniteapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EndpointGroupForPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, EndpointGroupStats, EndpointGroupForPath("/v2/stats/br/v2"))
+	assert.Equal(t, EndpointGroupCosmetics, EndpointGroupForPath("/v2/cosmetics/br/search"))
+	assert.Equal(t, EndpointGroupShop, EndpointGroupForPath("/v2/shop"))
+	assert.Equal(t, EndpointGroupDefault, EndpointGroupForPath("/v1/map"))
+	assert.Equal(t, EndpointGroupImages, EndpointGroupForPath("/images/cosmetics/br/cid_001/icon.png"))
+}
+
+func Test_TokenBucketLimiter_Throttles(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewTokenBucketLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupShop: {Rate: 50, Burst: 1},
+	})
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeTestData(t, w, nil)
+	}, WithRateLimiter(limiter))
+
+	start := time.Now()
+	for range 3 {
+		_, err := client.GetShop(testCtx, nil)
+		require.NoError(t, err)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func Test_TokenBucketLimiter_UnlimitedGroup(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewTokenBucketLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupStats: {Rate: 0.001, Burst: 1},
+	})
+
+	for range 5 {
+		require.NoError(t, limiter.Wait(testCtx, EndpointGroupShop))
+	}
+}
+
+func Test_TokenBucketLimiter_RespectsContext(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewTokenBucketLimiter(map[EndpointGroup]RateLimit{
+		EndpointGroupStats: {Rate: 0.001, Burst: 1},
+	})
+
+	require.NoError(t, limiter.Wait(testCtx, EndpointGroupStats))
+
+	ctx, cancel := context.WithTimeout(testCtx, 20*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx, EndpointGroupStats)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_TokenBucketLimiter_UpdateFromHeaders(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewTokenBucketLimiter(nil)
+	limiter.now = func() time.Time { return now }
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ratelimit-Limit":     []string{"10"},
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{"30"},
+		},
+	}
+
+	limiter.Update(EndpointGroupStats, response)
+
+	state := limiter.State()[EndpointGroupStats]
+	assert.Equal(t, 10, state.Burst)
+	assert.Equal(t, 0, state.Remaining)
+	assert.Equal(t, now.Add(30*time.Second), state.PausedUntil)
+}
+
+func Test_TokenBucketLimiter_PausesOnRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewTokenBucketLimiter(nil)
+	limiter.now = func() time.Time { return now }
+
+	limiter.Update(EndpointGroupShop, &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"5"}},
+	})
+
+	assert.Equal(t, now.Add(5*time.Second), limiter.State()[EndpointGroupShop].PausedUntil)
+}
@@ -0,0 +1,184 @@
+package fortniteapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+
+	// Methods lists the HTTP methods that may be retried.
+	//
+	// Default: GET, HEAD
+	Methods []string
+
+	// ShouldRetry overrides which responses and errors are retried.
+	// By default, network errors, 429 and 5xx responses are retried.
+	ShouldRetry func(response *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy is a reasonable policy for most callers. Retries are
+// off unless a policy is passed to NewClient with WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+var defaultRetryMethods = []string{http.MethodGet, http.MethodHead}
+
+// WithRetryPolicy retries failed requests according to policy, for example
+// WithRetryPolicy(DefaultRetryPolicy). Without it, every request is sent once.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+func (p *RetryPolicy) allowsMethod(method string) bool {
+	if len(p.Methods) == 0 {
+		return slices.Contains(defaultRetryMethods, method)
+	}
+
+	return slices.Contains(p.Methods, method)
+}
+
+func (p *RetryPolicy) retryable(response *http.Response, err error) bool {
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(response, err)
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError
+}
+
+func (p *RetryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if delay, ok := parseRetryAfter(response.Header.Get("Retry-After"), time.Now()); ok {
+			return p.capDelay(delay)
+		}
+	}
+
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	// A shift past the range of Duration overflows to zero or below.
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay>>(attempt-1) != p.BaseDelay {
+		return p.capDelay(p.MaxDelay)
+	}
+
+	delay = p.capDelay(delay)
+	half := delay / 2
+
+	return half + rand.N(half+1) //nolint:gosec
+}
+
+func (p *RetryPolicy) capDelay(delay time.Duration) time.Duration {
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return delay
+}
+
+func (c *Client) do(ctx context.Context, method, urlStr string, body any, header http.Header) (*http.Response, error) {
+	return c.send(ctx, method, urlStr, body, header, true)
+}
+
+// send is do with control over whether the API key is sent.
+func (c *Client) send(ctx context.Context, method, urlStr string, body any, header http.Header, authorize bool) (*http.Response, error) {
+	policy := c.retryPolicy
+
+	for attempt := 1; ; attempt++ {
+		request, err := c.newRequest(ctx, method, urlStr, body, authorize)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range header {
+			request.Header[key] = values
+		}
+
+		group := EndpointGroupForPath(request.URL.Path)
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx, group); err != nil {
+				return nil, fmt.Errorf("failed to wait for rate limiter: %w", err)
+			}
+		}
+
+		response, err := c.httpClient.Do(request)
+
+		if c.rateLimiter != nil {
+			c.rateLimiter.Update(group, response)
+		}
+
+		canRetry := attempt < policy.MaxAttempts && policy.allowsMethod(method) && ctx.Err() == nil
+		if !canRetry || !policy.retryable(response, err) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to send request: %w", err)
+			}
+
+			return response, nil
+		}
+
+		delay := policy.backoff(attempt, response)
+
+		if response != nil {
+			io.Copy(io.Discard, response.Body) //nolint:errcheck
+			response.Body.Close()              //nolint:errcheck
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+	}
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+package fortniteapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+func failingHandler(t *testing.T, failures int32, status int, calls *atomic.Int32) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"status":%d,"error":%q}`, status, http.StatusText(status))
+			return
+		}
+
+		writeTestData(t, w, nil)
+	}
+}
+
+func Test_Retry_SucceedsAfterServerErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, failingHandler(t, 2, http.StatusServiceUnavailable, &calls), WithRetryPolicy(testRetryPolicy))
+
+	_, err := client.GetShop(testCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func Test_Retry_GivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, failingHandler(t, 10, http.StatusTooManyRequests, &calls), WithRetryPolicy(testRetryPolicy))
+
+	_, err := client.GetShop(testCtx, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func Test_Retry_DisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, failingHandler(t, 1, http.StatusBadGateway, &calls))
+
+	_, err := client.GetShop(testCtx, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_Retry_SkipsPostByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	client := newTestClient(t, failingHandler(t, 1, http.StatusBadGateway, &calls), WithRetryPolicy(testRetryPolicy))
+
+	_, err := client.SearchBRCosmeticsByIDs(testCtx, []string{testCosmeticID1}, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_Retry_PostWhenAllowed(t *testing.T) {
+	t.Parallel()
+
+	policy := testRetryPolicy
+	policy.Methods = []string{http.MethodGet, http.MethodPost}
+
+	var calls atomic.Int32
+	client := newTestClient(t, failingHandler(t, 1, http.StatusBadGateway, &calls), WithRetryPolicy(policy))
+
+	_, err := client.SearchBRCosmeticsByIDs(testCtx, []string{testCosmeticID1}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func Test_Retry_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	policy := testRetryPolicy
+	policy.BaseDelay = time.Hour
+	policy.MaxDelay = time.Hour
+
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(testCtx, 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetShop(ctx, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_ParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("5", now)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, delay)
+
+	delay, ok = parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, delay)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	noDelay := RetryPolicy{MaxDelay: 30 * time.Second}
+	assert.Zero(t, noDelay.backoff(1, nil))
+	assert.Zero(t, noDelay.backoff(5, nil))
+
+	policy := RetryPolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+	delay := policy.backoff(2, nil)
+	assert.GreaterOrEqual(t, delay, time.Second)
+	assert.LessOrEqual(t, delay, 2*time.Second)
+
+	// Shifts that overflow wait the maximum delay.
+	delay = policy.backoff(64, nil)
+	assert.GreaterOrEqual(t, delay, 15*time.Second)
+	assert.LessOrEqual(t, delay, 30*time.Second)
+}
@@ -0,0 +1,177 @@
+package fortniteapi
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// ShopHistoryStats summarizes the shop appearances of a cosmetic. Days in
+// the shop in a row count as one stint, and absences are the days between
+// two stints.
+type ShopHistoryStats struct {
+	// Appearances is the number of distinct days in the shop.
+	Appearances int
+	Stints      int
+	FirstSeen   time.Time
+	LastSeen    time.Time
+
+	// AverageGapDays and GapDeviationDays are 0 with fewer than two stints.
+	AverageGapDays     float64
+	GapDeviationDays   float64
+	LongestAbsenceDays int
+
+	// CurrentAbsenceDays is the number of days since LastSeen, 0 while the
+	// cosmetic is in the shop.
+	CurrentAbsenceDays int
+
+	AppearancesPerYear map[int]int
+}
+
+// ReturnWindow is the range of days a cosmetic is likely to return in.
+type ReturnWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// ShopStats analyzes the history as of now.
+func (h ShopHistory) ShopStats(now time.Time) ShopHistoryStats {
+	return AnalyzeShopHistory(h.Times(), now)
+}
+
+// AnalyzeShopHistory analyzes appearance times as returned by
+// Cosmetic.ShopHistoryTimes.
+func AnalyzeShopHistory(times []time.Time, now time.Time) ShopHistoryStats {
+	stats := ShopHistoryStats{AppearancesPerYear: make(map[int]int)}
+
+	days := make([]time.Time, 0, len(times))
+	for _, appearance := range times {
+		days = append(days, appearance.UTC().Truncate(shopResetInterval))
+	}
+
+	slices.SortFunc(days, time.Time.Compare)
+	days = slices.Compact(days)
+
+	if len(days) == 0 {
+		return stats
+	}
+
+	stats.Appearances = len(days)
+	stats.Stints = 1
+	stats.FirstSeen = days[0]
+	stats.LastSeen = days[len(days)-1]
+	stats.CurrentAbsenceDays = max(daysBetween(stats.LastSeen, now), 0)
+
+	var gaps []int
+
+	for i, day := range days {
+		stats.AppearancesPerYear[day.Year()]++
+
+		if i == 0 {
+			continue
+		}
+
+		if gap := daysBetween(days[i-1], day) - 1; gap > 0 {
+			gaps = append(gaps, gap)
+			stats.Stints++
+			stats.LongestAbsenceDays = max(stats.LongestAbsenceDays, gap)
+		}
+	}
+
+	if len(gaps) == 0 {
+		return stats
+	}
+
+	var sum float64
+	for _, gap := range gaps {
+		sum += float64(gap)
+	}
+
+	stats.AverageGapDays = sum / float64(len(gaps))
+
+	var variance float64
+	for _, gap := range gaps {
+		variance += math.Pow(float64(gap)-stats.AverageGapDays, 2)
+	}
+
+	stats.GapDeviationDays = math.Sqrt(variance / float64(len(gaps)))
+
+	return stats
+}
+
+// PredictReturn estimates when the cosmetic returns, as the average gap
+// plus or minus one standard deviation after LastSeen. It needs at least
+// two stints.
+func (s ShopHistoryStats) PredictReturn() (ReturnWindow, bool) {
+	if s.Stints < 2 {
+		return ReturnWindow{}, false
+	}
+
+	day := func(days float64) time.Time {
+		// The day after LastSeen is the earliest possible return.
+		return s.LastSeen.AddDate(0, 0, 1+max(int(math.Round(days)), 0))
+	}
+
+	return ReturnWindow{
+		From: day(s.AverageGapDays - s.GapDeviationDays),
+		To:   day(s.AverageGapDays + s.GapDeviationDays),
+	}, true
+}
+
+// Overdue returns how many times its average gap the cosmetic has been
+// away, or 0 with fewer than two stints.
+func (s ShopHistoryStats) Overdue() float64 {
+	if s.Stints < 2 || s.AverageGapDays == 0 {
+		return 0
+	}
+
+	return float64(s.CurrentAbsenceDays) / s.AverageGapDays
+}
+
+type OverdueCosmetic struct {
+	Cosmetic Cosmetic
+	Stats    ShopHistoryStats
+
+	// Overdue is Stats.Overdue(), and DaysOverdue the days past the
+	// average gap.
+	Overdue     float64
+	DaysOverdue int
+}
+
+// MostOverdue ranks the cosmetics that have been away from the shop for
+// longer than their average gap, most overdue first. A limit of 0 or less
+// returns all of them.
+func MostOverdue(cosmetics *AllCosmeticsResponse, now time.Time, limit int) []OverdueCosmetic {
+	var ranked []OverdueCosmetic
+
+	for cosmetic := range cosmetics.Iter() {
+		stats := AnalyzeShopHistory(cosmetic.ShopHistoryTimes(), now)
+
+		overdue := stats.Overdue()
+		if overdue <= 1 {
+			continue
+		}
+
+		ranked = append(ranked, OverdueCosmetic{
+			Cosmetic:    cosmetic,
+			Stats:       stats,
+			Overdue:     overdue,
+			DaysOverdue: stats.CurrentAbsenceDays - int(math.Round(stats.AverageGapDays)),
+		})
+	}
+
+	slices.SortStableFunc(ranked, func(a, b OverdueCosmetic) int {
+		if c := cmp.Compare(b.Overdue, a.Overdue); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(b.DaysOverdue, a.DaysOverdue)
+	})
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return ranked
+}
@@ -0,0 +1,62 @@
+package fortniteapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShopHistory_ShopStats(t *testing.T) {
+	t.Parallel()
+
+	history := ShopHistory{
+		"2024-12-30T00:00:00Z",
+		"2025-01-01T00:00:00Z",
+		"2025-01-02T00:00:00Z",
+		"2025-01-02T00:00:00Z",
+		"2025-01-12T00:00:00Z",
+		"2025-01-26T00:00:00Z",
+	}
+
+	stats := history.ShopStats(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 5, stats.Appearances)
+	assert.Equal(t, 4, stats.Stints)
+	assert.Equal(t, 13, stats.LongestAbsenceDays)
+	assert.InDelta(t, 23.0/3, stats.AverageGapDays, 0.0001)
+	assert.Equal(t, 34, stats.CurrentAbsenceDays)
+	assert.Equal(t, map[int]int{2024: 1, 2025: 4}, stats.AppearancesPerYear)
+
+	window, ok := stats.PredictReturn()
+	require.True(t, ok)
+	assert.True(t, window.From.Before(window.To))
+	assert.False(t, window.From.Before(stats.LastSeen.AddDate(0, 0, 1)))
+
+	_, ok = ShopHistory{"2025-01-01T00:00:00Z"}.ShopStats(time.Now()).PredictReturn()
+	assert.False(t, ok)
+}
+
+func Test_MostOverdue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 28, 0, 0, 0, 0, time.UTC)
+
+	cosmetics := &AllCosmeticsResponse{
+		BR: []BRCosmetic{
+			{ID: "regular", ShopHistory: ShopHistory{"2025-05-01T00:00:00Z", "2025-05-11T00:00:00Z", "2025-05-21T00:00:00Z"}},
+			{ID: "overdue", ShopHistory: ShopHistory{"2025-01-01T00:00:00Z", "2025-01-11T00:00:00Z", "2025-01-21T00:00:00Z"}},
+			{ID: "once", ShopHistory: ShopHistory{"2020-01-01T00:00:00Z"}},
+		},
+		Cars: []Car{{ID: "car", ShopHistory: ShopHistory{"2025-03-01T00:00:00Z", "2025-03-31T00:00:00Z"}}},
+	}
+
+	ranked := MostOverdue(cosmetics, now, 0)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "overdue", ranked[0].Cosmetic.ItemID())
+	assert.Equal(t, "car", ranked[1].Cosmetic.ItemID())
+	assert.Greater(t, ranked[0].DaysOverdue, 100)
+
+	assert.Len(t, MostOverdue(cosmetics, now, 1), 1)
+}
@@ -0,0 +1,330 @@
+package fortniteapi
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TileSize is the size of a shop tile in grid units.
+type TileSize struct {
+	Width  int
+	Height int
+}
+
+// ParseTileSize parses a ShopItem.TileSize such as "Size_1_x_2".
+func ParseTileSize(value string) (TileSize, bool) {
+	rest, ok := strings.CutPrefix(value, "Size_")
+	if !ok {
+		return TileSize{}, false
+	}
+
+	width, height, ok := strings.Cut(rest, "_x_")
+	if !ok {
+		return TileSize{}, false
+	}
+
+	w, err := strconv.Atoi(width)
+	if err != nil || w <= 0 {
+		return TileSize{}, false
+	}
+
+	h, err := strconv.Atoi(height)
+	if err != nil || h <= 0 {
+		return TileSize{}, false
+	}
+
+	return TileSize{Width: w, Height: h}, true
+}
+
+// Tile returns the tile size of the entry, 1 by 1 when TileSize is missing
+// or invalid.
+func (s *ShopItem) Tile() TileSize {
+	if size, ok := ParseTileSize(s.TileSize); ok {
+		return size
+	}
+
+	return TileSize{Width: 1, Height: 1}
+}
+
+// Layout metadata keys resolved into the fields of ShopSectionMetadata.
+// Keys are matched case-insensitively.
+const (
+	ShopSectionBackgroundTextureKey   = "Background"
+	ShopSectionBackgroundColorAKey    = "BackgroundColorA"
+	ShopSectionBackgroundColorBKey    = "BackgroundColorB"
+	ShopSectionTextColorKey           = "TextColor"
+	ShopSectionTextBackgroundColorKey = "TextBackgroundColor"
+	ShopSectionSubHeaderKey           = "SubHeader"
+)
+
+// ShopSectionMetadata holds the layout metadata of a section. Colors are
+// hex strings, like the colors of ShopItem.
+type ShopSectionMetadata struct {
+	// BackgroundTexture is the texture metadata of the background.
+	BackgroundTexture string
+
+	// BackgroundColorA and BackgroundColorB are the top and bottom colors
+	// of the background gradient.
+	BackgroundColorA string
+	BackgroundColorB string
+
+	TextColor           string
+	TextBackgroundColor string
+
+	// SubHeader is the text shown under the section name.
+	SubHeader string
+
+	// Textures, Strings and Text hold every entry by key, as a fallback for
+	// keys without a field above.
+	Textures map[string]string
+	Strings  map[string]string
+	Text     map[string]string
+}
+
+type ShopSection struct {
+	ID       string
+	Name     string
+	Category string
+	Index    int
+	Rank     int
+
+	Background           string
+	UseWidePreview       bool
+	DisplayType          string
+	ShowIneligibleOffers string
+	Metadata             ShopSectionMetadata
+
+	// Entries are ordered by SortPriority, highest first.
+	Entries []ShopItem
+}
+
+type ShopCategory struct {
+	Name     string
+	Sections []ShopSection
+}
+
+// ShopLayout is the shop as the game shows it: categories of sections of
+// entries.
+type ShopLayout struct {
+	Categories []ShopCategory
+}
+
+// Layout groups the entries by Layout. Sections are ordered by Index, then
+// by Rank from highest to lowest, and categories follow their first
+// section. Entries without a layout end up in a last section with an
+// empty ID.
+func (r *ShopResponse) Layout() ShopLayout {
+	var (
+		sections []*ShopSection
+		byID     = make(map[string]*ShopSection)
+	)
+
+	for _, entry := range r.Entries {
+		id := entry.Layout.ID
+		if id == "" {
+			id = entry.LayoutID
+		}
+
+		section, ok := byID[id]
+		if !ok {
+			section = newShopSection(id, entry.Layout)
+			byID[id] = section
+			sections = append(sections, section)
+		}
+
+		section.Entries = append(section.Entries, entry)
+	}
+
+	slices.SortStableFunc(sections, func(a, b *ShopSection) int {
+		if (a.ID == "") != (b.ID == "") {
+			if a.ID == "" {
+				return 1
+			}
+
+			return -1
+		}
+
+		if c := cmp.Compare(a.Index, b.Index); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(b.Rank, a.Rank)
+	})
+
+	var layout ShopLayout
+
+	categories := make(map[string]int)
+
+	for _, section := range sections {
+		slices.SortStableFunc(section.Entries, func(a, b ShopItem) int {
+			return cmp.Compare(b.SortPriority, a.SortPriority)
+		})
+
+		i, ok := categories[section.Category]
+		if !ok {
+			i = len(layout.Categories)
+			categories[section.Category] = i
+			layout.Categories = append(layout.Categories, ShopCategory{Name: section.Category})
+		}
+
+		layout.Categories[i].Sections = append(layout.Categories[i].Sections, *section)
+	}
+
+	return layout
+}
+
+func newShopSection(id string, layout ShopItemLayout) *ShopSection {
+	section := &ShopSection{
+		ID:                   id,
+		Name:                 layout.Name,
+		Category:             layout.Category,
+		Index:                layout.Index,
+		Rank:                 layout.Rank,
+		Background:           layout.Background,
+		UseWidePreview:       layout.UseWidePreview,
+		DisplayType:          layout.DisplayType,
+		ShowIneligibleOffers: layout.ShowIneligibleOffers,
+		Metadata: ShopSectionMetadata{
+			Textures: make(map[string]string, len(layout.TextureMetadata)),
+			Strings:  make(map[string]string, len(layout.StringMetadata)),
+			Text:     make(map[string]string, len(layout.TextMetadata)),
+		},
+	}
+
+	// The folded maps follow the response order, so when two keys only
+	// differ by case the later one wins, like for repeated keys.
+	var (
+		textures = make(map[string]string, len(layout.TextureMetadata))
+		strs     = make(map[string]string, len(layout.StringMetadata))
+		text     = make(map[string]string, len(layout.TextMetadata))
+	)
+
+	for _, metadata := range layout.TextureMetadata {
+		section.Metadata.Textures[metadata.Key] = metadata.Value
+		textures[strings.ToLower(metadata.Key)] = metadata.Value
+	}
+
+	for _, metadata := range layout.StringMetadata {
+		section.Metadata.Strings[metadata.Key] = metadata.Value
+		strs[strings.ToLower(metadata.Key)] = metadata.Value
+	}
+
+	for _, metadata := range layout.TextMetadata {
+		section.Metadata.Text[metadata.Key] = metadata.Value
+		text[strings.ToLower(metadata.Key)] = metadata.Value
+	}
+
+	metadata := &section.Metadata
+	metadata.BackgroundTexture = metadataValue(metadata.Textures, textures, ShopSectionBackgroundTextureKey)
+	metadata.BackgroundColorA = metadataValue(metadata.Strings, strs, ShopSectionBackgroundColorAKey)
+	metadata.BackgroundColorB = metadataValue(metadata.Strings, strs, ShopSectionBackgroundColorBKey)
+	metadata.TextColor = metadataValue(metadata.Strings, strs, ShopSectionTextColorKey)
+	metadata.TextBackgroundColor = metadataValue(metadata.Strings, strs, ShopSectionTextBackgroundColorKey)
+	metadata.SubHeader = metadataValue(metadata.Text, text, ShopSectionSubHeaderKey)
+
+	return section
+}
+
+// metadataValue returns the value of key, or of the key matching it
+// case-insensitively in folded.
+func metadataValue(values, folded map[string]string, key string) string {
+	if value, ok := values[key]; ok {
+		return value
+	}
+
+	return folded[strings.ToLower(key)]
+}
+
+// Sections returns every section in order.
+func (l ShopLayout) Sections() []ShopSection {
+	var sections []ShopSection
+	for _, category := range l.Categories {
+		sections = append(sections, category.Sections...)
+	}
+
+	return sections
+}
+
+// Section returns the section with the given layout ID.
+func (l ShopLayout) Section(id string) (ShopSection, bool) {
+	for _, category := range l.Categories {
+		for _, section := range category.Sections {
+			if section.ID == id {
+				return section, true
+			}
+		}
+	}
+
+	return ShopSection{}, false
+}
+
+// ShopTile is an entry placed on the grid of a section, in grid units.
+type ShopTile struct {
+	Entry  *ShopItem
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Grid places the entries of the section on a grid with the given number
+// of columns, in order, each tile at the first free spot from the top
+// left. Tiles wider than the grid are narrowed to fit. It returns the
+// tiles and the number of rows used.
+func (s *ShopSection) Grid(columns int) ([]ShopTile, int) {
+	columns = max(columns, 1)
+
+	var (
+		tiles    []ShopTile
+		occupied [][]bool
+		rows     int
+	)
+
+	free := func(x, y int, size TileSize) bool {
+		for row := y; row < y+size.Height && row < len(occupied); row++ {
+			for column := x; column < x+size.Width; column++ {
+				if occupied[row][column] {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	place := func(x, y int, size TileSize) {
+		for len(occupied) < y+size.Height {
+			occupied = append(occupied, make([]bool, columns))
+		}
+
+		for row := y; row < y+size.Height; row++ {
+			for column := x; column < x+size.Width; column++ {
+				occupied[row][column] = true
+			}
+		}
+
+		rows = max(rows, y+size.Height)
+	}
+
+	for i := range s.Entries {
+		size := s.Entries[i].Tile()
+		size.Width = min(size.Width, columns)
+
+		placed := false
+		for y := 0; !placed; y++ {
+			for x := 0; x+size.Width <= columns; x++ {
+				if free(x, y, size) {
+					place(x, y, size)
+					tiles = append(tiles, ShopTile{Entry: &s.Entries[i], X: x, Y: y, Width: size.Width, Height: size.Height})
+					placed = true
+
+					break
+				}
+			}
+		}
+	}
+
+	return tiles, rows
+}
@@ -0,0 +1,154 @@
+package fortniteapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseTileSize(t *testing.T) {
+	t.Parallel()
+
+	size, ok := ParseTileSize("Size_2_x_1")
+	require.True(t, ok)
+	assert.Equal(t, TileSize{Width: 2, Height: 1}, size)
+
+	for _, invalid := range []string{"", "Size_", "Size_a_x_1", "Size_0_x_1", "Normal"} {
+		_, ok := ParseTileSize(invalid)
+		assert.False(t, ok, invalid)
+	}
+
+	assert.Equal(t, TileSize{Width: 1, Height: 1}, (&ShopItem{TileSize: "DoubleWide"}).Tile())
+}
+
+func Test_ShopResponse_Layout(t *testing.T) {
+	t.Parallel()
+
+	jam := ShopItemLayout{ID: "jam", Name: "Jam Tracks", Category: "Music", Index: 5}
+	featured := ShopItemLayout{
+		ID:              "featured",
+		Name:            "Featured",
+		Category:        "Top",
+		Index:           1,
+		TextureMetadata: []ShopItemLayoutTextureMetadata{{Key: "Background", Value: "bg.png"}},
+		StringMetadata: []ShopItemLayoutStringMetadata{
+			{Key: "backgroundColorA", Value: "ff0000"},
+			{Key: "BackgroundColorB", Value: "0000ff"},
+			{Key: "TextColor", Value: "ffffff"},
+			{Key: "Custom", Value: "value"},
+		},
+		TextMetadata: []ShopItemLayoutTextMetadata{{Key: "SubHeader", Value: "Ends soon"}},
+	}
+	daily := ShopItemLayout{ID: "daily", Name: "Daily", Category: "Top", Index: 9}
+
+	shop := &ShopResponse{Entries: []ShopItem{
+		{OfferID: "j1", Layout: jam, SortPriority: 1},
+		{OfferID: "f1", Layout: featured, SortPriority: 10},
+		{OfferID: "d1", Layout: daily},
+		{OfferID: "loose"},
+		{OfferID: "f2", Layout: featured, SortPriority: 20},
+	}}
+
+	layout := shop.Layout()
+	require.Len(t, layout.Categories, 3)
+	assert.Equal(t, "Top", layout.Categories[0].Name)
+	assert.Equal(t, "Music", layout.Categories[1].Name)
+
+	top := layout.Categories[0].Sections
+	require.Len(t, top, 2)
+	assert.Equal(t, "featured", top[0].ID)
+	assert.Equal(t, "daily", top[1].ID)
+	assert.Equal(t, "f2", top[0].Entries[0].OfferID)
+
+	metadata := top[0].Metadata
+	assert.Equal(t, "bg.png", metadata.BackgroundTexture)
+	assert.Equal(t, "ff0000", metadata.BackgroundColorA)
+	assert.Equal(t, "0000ff", metadata.BackgroundColorB)
+	assert.Equal(t, "ffffff", metadata.TextColor)
+	assert.Empty(t, metadata.TextBackgroundColor)
+	assert.Equal(t, "Ends soon", metadata.SubHeader)
+	assert.Equal(t, "value", metadata.Strings["Custom"])
+
+	sections := layout.Sections()
+	require.Len(t, sections, 4)
+	assert.Empty(t, sections[3].ID)
+
+	_, ok := layout.Section("jam")
+	assert.True(t, ok)
+}
+
+func Test_ShopSection_Grid(t *testing.T) {
+	t.Parallel()
+
+	section := ShopSection{Entries: []ShopItem{
+		{OfferID: "big", TileSize: "Size_2_x_2"},
+		{OfferID: "a", TileSize: "Size_1_x_1"},
+		{OfferID: "b", TileSize: "Size_1_x_1"},
+		{OfferID: "wide", TileSize: "Size_3_x_1"},
+		{OfferID: "c", TileSize: "Size_1_x_1"},
+	}}
+
+	tiles, rows := section.Grid(3)
+	require.Len(t, tiles, 5)
+	assert.Equal(t, 4, rows)
+
+	assert.Equal(t, [2]int{0, 0}, [2]int{tiles[0].X, tiles[0].Y})
+	assert.Equal(t, [2]int{2, 0}, [2]int{tiles[1].X, tiles[1].Y})
+	assert.Equal(t, [2]int{2, 1}, [2]int{tiles[2].X, tiles[2].Y})
+	assert.Equal(t, [2]int{0, 2}, [2]int{tiles[3].X, tiles[3].Y})
+	assert.Equal(t, [2]int{0, 3}, [2]int{tiles[4].X, tiles[4].Y})
+	assert.Equal(t, "wide", tiles[3].Entry.OfferID)
+}
+
+func Test_ShopResponse_LayoutMetadataFromJSON(t *testing.T) {
+	t.Parallel()
+
+	var entry ShopItem
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"offerId": "v2:/offer",
+		"layout": {
+			"id": "featured",
+			"name": "Featured",
+			"category": "Top",
+			"index": 1,
+			"textureMetadata": [{"key": "Background", "value": "bg.png"}],
+			"stringMetadata": [
+				{"key": "backgroundcolora", "value": "111111"},
+				{"key": "BACKGROUNDCOLORA", "value": "222222"},
+				{"key": "BackgroundColorB", "value": "0000ff"},
+				{"key": "TextColor", "value": "ffffff"},
+				{"key": "TextBackgroundColor", "value": "000000"}
+			],
+			"textMetadata": [{"key": "SubHeader", "value": "Ends soon"}]
+		}
+	}`), &entry))
+
+	shop := &ShopResponse{Entries: []ShopItem{entry}}
+
+	// Keys that only differ by case resolve to the later one every time.
+	for range 20 {
+		metadata := shop.Layout().Sections()[0].Metadata
+		assert.Equal(t, "222222", metadata.BackgroundColorA)
+	}
+
+	metadata := shop.Layout().Sections()[0].Metadata
+	assert.Equal(t, ShopSectionMetadata{
+		BackgroundTexture:   "bg.png",
+		BackgroundColorA:    "222222",
+		BackgroundColorB:    "0000ff",
+		TextColor:           "ffffff",
+		TextBackgroundColor: "000000",
+		SubHeader:           "Ends soon",
+		Textures:            map[string]string{"Background": "bg.png"},
+		Strings: map[string]string{
+			"backgroundcolora":    "111111",
+			"BACKGROUNDCOLORA":    "222222",
+			"BackgroundColorB":    "0000ff",
+			"TextColor":           "ffffff",
+			"TextBackgroundColor": "000000",
+		},
+		Text: map[string]string{"SubHeader": "Ends soon"},
+	}, metadata)
+}
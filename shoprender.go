@@ -0,0 +1,563 @@
+package fortniteapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // Decode JPEG images.
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNoTextDrawer is returned by the renderers when no TextDrawer is set,
+// since an image without names and prices isn't useful.
+var ErrNoTextDrawer = errors.New("no text drawer configured")
+
+type TextAlign int
+
+const (
+	TextAlignLeft TextAlign = iota
+	TextAlignCenter
+	TextAlignRight
+)
+
+type TextStyle struct {
+	Size  float64
+	Color color.Color
+	Bold  bool
+	Align TextAlign
+
+	// Strikethrough is used for regular prices next to discounted ones.
+	Strikethrough bool
+}
+
+// TextDrawer draws text into a rectangle. The standard library has no
+// font rendering, so plug in one based on a font package of your choice.
+type TextDrawer interface {
+	DrawText(dst draw.Image, text string, rect image.Rectangle, style TextStyle)
+}
+
+type TextDrawerFunc func(dst draw.Image, text string, rect image.Rectangle, style TextStyle)
+
+func (f TextDrawerFunc) DrawText(dst draw.Image, text string, rect image.Rectangle, style TextStyle) {
+	f(dst, text, rect, style)
+}
+
+// ShopTemplate holds the geometry and colors of a shop image. Sizes are in
+// pixels.
+type ShopTemplate struct {
+	// UnitWidth and UnitHeight are the size of a 1 by 1 tile.
+	//
+	// Default: 256 by 256
+	UnitWidth  int
+	UnitHeight int
+
+	// Columns is the width of a section in tile units.
+	//
+	// Default: 4
+	Columns int
+
+	// Default: 12
+	Gap int
+
+	// Default: 32
+	Padding int
+
+	// Default: 56
+	HeaderHeight int
+
+	// InfoHeight is the height of the name and price strip of a tile.
+	//
+	// Default: 64
+	InfoHeight int
+
+	// Default: dark blue
+	Background color.Color
+
+	// Default: white
+	TextColor color.Color
+
+	// Default: 24
+	HeaderTextSize float64
+
+	// Default: 18
+	TileTextSize float64
+}
+
+func (t *ShopTemplate) setDefaults() {
+	if t.UnitWidth <= 0 {
+		t.UnitWidth = 256
+	}
+
+	if t.UnitHeight <= 0 {
+		t.UnitHeight = 256
+	}
+
+	if t.Columns <= 0 {
+		t.Columns = 4
+	}
+
+	if t.Gap <= 0 {
+		t.Gap = 12
+	}
+
+	if t.Padding <= 0 {
+		t.Padding = 32
+	}
+
+	if t.HeaderHeight <= 0 {
+		t.HeaderHeight = 56
+	}
+
+	if t.InfoHeight <= 0 {
+		t.InfoHeight = 64
+	}
+
+	if t.Background == nil {
+		t.Background = color.RGBA{R: 0x10, G: 0x18, B: 0x30, A: 0xff}
+	}
+
+	if t.TextColor == nil {
+		t.TextColor = color.White
+	}
+
+	if t.HeaderTextSize <= 0 {
+		t.HeaderTextSize = 24
+	}
+
+	if t.TileTextSize <= 0 {
+		t.TileTextSize = 18
+	}
+}
+
+// tileRect returns the pixel rectangle of a tile placed at x, y in a
+// section starting at origin.
+func (t *ShopTemplate) tileRect(origin image.Point, tile ShopTile) image.Rectangle {
+	minPoint := origin.Add(image.Pt(tile.X*(t.UnitWidth+t.Gap), tile.Y*(t.UnitHeight+t.Gap)))
+	size := image.Pt(tile.Width*t.UnitWidth+(tile.Width-1)*t.Gap, tile.Height*t.UnitHeight+(tile.Height-1)*t.Gap)
+
+	return image.Rectangle{Min: minPoint, Max: minPoint.Add(size)}
+}
+
+func (t *ShopTemplate) sectionHeight(rows int) int {
+	return t.HeaderHeight + rows*t.UnitHeight + max(rows-1, 0)*t.Gap
+}
+
+type ShopRendererOptions struct {
+	// Fetcher downloads the images. A *Client works.
+	Fetcher ImageFetcher
+
+	// Text draws the names, prices, banners and section headers. Render
+	// fails with ErrNoTextDrawer without it.
+	Text TextDrawer
+
+	Template ShopTemplate
+
+	// Concurrency is the number of images downloaded at once.
+	//
+	// Default: 8
+	Concurrency int
+
+	// OnError is called for images that fail to download or decode. The
+	// tile is drawn without them.
+	OnError func(url string, err error)
+}
+
+// ShopRenderer draws the item shop into a single image, section by section
+// as ShopResponse.Layout orders them.
+type ShopRenderer struct {
+	options ShopRendererOptions
+}
+
+func NewShopRenderer(options ShopRendererOptions) *ShopRenderer {
+	options.Template.setDefaults()
+
+	if options.Concurrency <= 0 {
+		options.Concurrency = 8
+	}
+
+	return &ShopRenderer{options: options}
+}
+
+// RenderPNG renders the shop and writes it as PNG.
+func (r *ShopRenderer) RenderPNG(ctx context.Context, shop *ShopResponse, w io.Writer) error {
+	img, err := r.Render(ctx, shop)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to encode shop image: %w", err)
+	}
+
+	return nil
+}
+
+// Render draws the shop. Sections use the background and text colors and
+// the sub header of their layout metadata when the shop has them.
+func (r *ShopRenderer) Render(ctx context.Context, shop *ShopResponse) (*image.RGBA, error) {
+	if r.options.Text == nil {
+		return nil, ErrNoTextDrawer
+	}
+
+	template := &r.options.Template
+	sections := shop.Layout().Sections()
+
+	type placedSection struct {
+		section ShopSection
+		tiles   []ShopTile
+		top     int
+		height  int
+	}
+
+	placed := make([]placedSection, 0, len(sections))
+	height := template.Padding
+
+	for _, section := range sections {
+		tiles, rows := section.Grid(template.Columns)
+		placed = append(placed, placedSection{section: section, tiles: tiles, top: height, height: template.sectionHeight(rows)})
+		height += template.sectionHeight(rows) + template.Padding
+	}
+
+	width := 2*template.Padding + template.Columns*template.UnitWidth + (template.Columns-1)*template.Gap
+
+	urls := []string{shop.VBuckIcon}
+	for _, section := range sections {
+		for i := range section.Entries {
+			urls = append(urls, shopEntryImageURL(&section.Entries[i]))
+		}
+	}
+
+	images, err := fetchImages(ctx, r.options.Fetcher, urls, r.options.Concurrency, r.options.OnError)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(canvas, canvas.Bounds(), image.NewUniform(template.Background), image.Point{}, draw.Src)
+
+	for _, p := range placed {
+		r.drawSectionHeader(canvas, image.Rect(0, p.top, width, p.top+p.height), p.section)
+
+		origin := image.Pt(template.Padding, p.top+template.HeaderHeight)
+
+		for _, tile := range p.tiles {
+			rect := template.tileRect(origin, tile)
+			r.drawTile(canvas, rect, tile.Entry, images[shopEntryImageURL(tile.Entry)], images[shop.VBuckIcon])
+		}
+	}
+
+	return canvas, nil
+}
+
+// drawSectionHeader draws the background of the section over rect and its
+// name and sub header at the top.
+func (r *ShopRenderer) drawSectionHeader(canvas *image.RGBA, rect image.Rectangle, section ShopSection) {
+	template := &r.options.Template
+	metadata := section.Metadata
+
+	if top, ok := parseHexColor(metadata.BackgroundColorA); ok {
+		bottom, ok := parseHexColor(metadata.BackgroundColorB)
+		if !ok {
+			bottom = top
+		}
+
+		drawGradient(canvas, rect, top, bottom)
+	}
+
+	header := image.Rect(template.Padding, rect.Min.Y, rect.Max.X-template.Padding, rect.Min.Y+template.HeaderHeight)
+
+	if background, ok := parseHexColor(metadata.TextBackgroundColor); ok {
+		draw.Draw(canvas, header, image.NewUniform(background), image.Point{}, draw.Over)
+	}
+
+	textColor := template.TextColor
+	if parsed, ok := parseHexColor(metadata.TextColor); ok {
+		textColor = parsed
+	}
+
+	name := header
+	if metadata.SubHeader != "" {
+		name.Max.Y = header.Min.Y + header.Dy()*3/5
+	}
+
+	r.drawText(canvas, section.Name, name, TextStyle{Size: template.HeaderTextSize, Color: textColor, Bold: true})
+
+	subHeader := image.Rect(header.Min.X, name.Max.Y, header.Max.X, header.Max.Y)
+	r.drawText(canvas, metadata.SubHeader, subHeader, TextStyle{Size: template.HeaderTextSize * 0.6, Color: textColor})
+}
+
+func (r *ShopRenderer) drawTile(canvas *image.RGBA, rect image.Rectangle, entry *ShopItem, render, vbuck image.Image) {
+	template := &r.options.Template
+
+	top, ok := parseHexColor(entry.Colors.Color1)
+	if !ok {
+		top = color.NRGBA{R: 0x40, G: 0x50, B: 0x70, A: 0xff}
+	}
+
+	bottom, ok := parseHexColor(entry.Colors.Color2)
+	if !ok {
+		bottom = top
+	}
+
+	drawGradient(canvas, rect, top, bottom)
+
+	if render != nil {
+		drawContain(canvas, rect, render)
+	}
+
+	info := image.Rect(rect.Min.X, rect.Max.Y-template.InfoHeight, rect.Max.X, rect.Max.Y)
+
+	infoBackground, ok := parseHexColor(entry.Colors.TextBackgroundColor)
+	if !ok {
+		infoBackground = color.NRGBA{A: 0xff}
+	}
+
+	infoBackground.A = 0xc0
+	draw.Draw(canvas, info, image.NewUniform(infoBackground), image.Point{}, draw.Over)
+
+	half := info.Dy() / 2
+	nameRect := image.Rect(info.Min.X+8, info.Min.Y, info.Max.X-8, info.Min.Y+half)
+	priceRect := image.Rect(info.Min.X+8, info.Min.Y+half, info.Max.X-8, info.Max.Y)
+
+	style := TextStyle{Size: template.TileTextSize, Color: template.TextColor, Align: TextAlignCenter}
+	r.drawText(canvas, shopEntryName(entry), nameRect, TextStyle{Size: style.Size, Color: style.Color, Bold: true, Align: TextAlignCenter})
+
+	if vbuck != nil {
+		icon := image.Rect(priceRect.Min.X, priceRect.Min.Y, priceRect.Min.X+priceRect.Dy(), priceRect.Max.Y)
+		drawContain(canvas, icon, vbuck)
+		priceRect.Min.X = icon.Max.X + 4
+	}
+
+	if entry.RegularPrice > entry.FinalPrice {
+		priceWidth := priceRect.Dx() / 2
+		r.drawText(canvas, strconv.Itoa(entry.FinalPrice), image.Rect(priceRect.Min.X, priceRect.Min.Y, priceRect.Min.X+priceWidth, priceRect.Max.Y),
+			TextStyle{Size: style.Size, Color: style.Color, Align: TextAlignLeft})
+		r.drawText(canvas, strconv.Itoa(entry.RegularPrice), image.Rect(priceRect.Min.X+priceWidth, priceRect.Min.Y, priceRect.Max.X, priceRect.Max.Y),
+			TextStyle{Size: style.Size * 0.8, Color: color.Gray{Y: 0xa0}, Align: TextAlignLeft, Strikethrough: true})
+	} else {
+		r.drawText(canvas, strconv.Itoa(entry.FinalPrice), priceRect, TextStyle{Size: style.Size, Color: style.Color, Align: TextAlignLeft})
+	}
+
+	if entry.Banner.Value != "" {
+		r.drawBanner(canvas, rect, entry.Banner)
+	}
+}
+
+func (r *ShopRenderer) drawBanner(canvas *image.RGBA, rect image.Rectangle, banner ShopItemBanner) {
+	template := &r.options.Template
+
+	background := color.RGBA{R: 0x1c, G: 0x6c, B: 0xd6, A: 0xff}
+	if strings.EqualFold(banner.Intensity, "High") {
+		background = color.RGBA{R: 0xff, G: 0xd6, B: 0x00, A: 0xff}
+	}
+
+	height := int(template.TileTextSize * 1.6)
+	bannerRect := image.Rect(rect.Min.X+6, rect.Min.Y+6, min(rect.Min.X+6+rect.Dx()*2/3, rect.Max.X), rect.Min.Y+6+height)
+
+	draw.Draw(canvas, bannerRect, image.NewUniform(background), image.Point{}, draw.Src)
+
+	textColor := template.TextColor
+	if strings.EqualFold(banner.Intensity, "High") {
+		textColor = color.Black
+	}
+
+	r.drawText(canvas, banner.Value, bannerRect.Inset(4), TextStyle{Size: template.TileTextSize * 0.8, Color: textColor, Bold: true})
+}
+
+func (r *ShopRenderer) drawText(canvas draw.Image, text string, rect image.Rectangle, style TextStyle) {
+	if text != "" {
+		r.options.Text.DrawText(canvas, text, rect, style)
+	}
+}
+
+// shopEntryImageURL picks the render image of the entry, then the bundle
+// image, then the image of the first item.
+func shopEntryImageURL(entry *ShopItem) string {
+	for _, render := range entry.NewDisplayAsset.RenderImages {
+		if render.Image != "" {
+			return render.Image
+		}
+	}
+
+	if entry.Bundle.Image != "" {
+		return entry.Bundle.Image
+	}
+
+	if cosmetic := shopEntryFirstCosmetic(entry); cosmetic != nil {
+		return cosmetic.BestImageURL()
+	}
+
+	return ""
+}
+
+func shopEntryName(entry *ShopItem) string {
+	if entry.Bundle.Name != "" {
+		return entry.Bundle.Name
+	}
+
+	if cosmetic := shopEntryFirstCosmetic(entry); cosmetic != nil {
+		return cosmetic.DisplayName()
+	}
+
+	return entry.DevName
+}
+
+func shopEntryFirstCosmetic(entry *ShopItem) Cosmetic {
+	switch {
+	case len(entry.BRItems) > 0:
+		return &entry.BRItems[0]
+	case len(entry.Tracks) > 0:
+		return &entry.Tracks[0]
+	case len(entry.Instruments) > 0:
+		return &entry.Instruments[0]
+	case len(entry.Cars) > 0:
+		return &entry.Cars[0]
+	case len(entry.LegoKits) > 0:
+		return &entry.LegoKits[0]
+	default:
+		return nil
+	}
+}
+
+// fetchImages downloads and decodes the distinct non-empty URLs. Failures
+// are reported to onError and left out of the result.
+func fetchImages(ctx context.Context, fetcher ImageFetcher, urls []string, concurrency int, onError func(string, error)) (map[string]image.Image, error) {
+	images := make(map[string]image.Image)
+	if fetcher == nil {
+		return images, nil
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan string)
+	)
+
+	for range concurrency {
+		wg.Go(func() {
+			for imageURL := range jobs {
+				img, err := fetchImage(ctx, fetcher, imageURL)
+
+				mu.Lock()
+				if err == nil {
+					images[imageURL] = img
+				}
+				mu.Unlock()
+
+				if err != nil && onError != nil && ctx.Err() == nil {
+					onError(imageURL, err)
+				}
+			}
+		})
+	}
+
+	seen := make(map[string]struct{})
+
+	for _, imageURL := range urls {
+		if _, ok := seen[imageURL]; ok || imageURL == "" {
+			continue
+		}
+
+		seen[imageURL] = struct{}{}
+		jobs <- imageURL
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return images, ctx.Err()
+}
+
+func fetchImage(ctx context.Context, fetcher ImageFetcher, imageURL string) (image.Image, error) {
+	data, err := fetcher.FetchImage(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	return img, nil
+}
+
+// parseHexColor parses "rrggbb" or "rrggbbaa", with or without "#".
+func parseHexColor(value string) (color.NRGBA, bool) {
+	value = strings.TrimPrefix(value, "#")
+	if len(value) != 6 && len(value) != 8 {
+		return color.NRGBA{}, false
+	}
+
+	if len(value) == 6 {
+		value += "ff"
+	}
+
+	parsed, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+
+	return color.NRGBA{R: uint8(parsed >> 24), G: uint8(parsed >> 16), B: uint8(parsed >> 8), A: uint8(parsed)}, true
+}
+
+func drawGradient(dst draw.Image, rect image.Rectangle, top, bottom color.NRGBA) {
+	height := max(rect.Dy()-1, 1)
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		t := float64(y-rect.Min.Y) / float64(height)
+		line := color.NRGBA{
+			R: lerp(top.R, bottom.R, t),
+			G: lerp(top.G, bottom.G, t),
+			B: lerp(top.B, bottom.B, t),
+			A: lerp(top.A, bottom.A, t),
+		}
+
+		draw.Draw(dst, image.Rect(rect.Min.X, y, rect.Max.X, y+1), image.NewUniform(line), image.Point{}, draw.Src)
+	}
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
+
+// drawContain scales src to fit inside rect, keeping its aspect ratio, and
+// draws it centered over dst. Scaling is nearest neighbor, as the standard
+// library has no resampling.
+func drawContain(dst draw.Image, rect image.Rectangle, src image.Image) {
+	bounds := src.Bounds()
+	if bounds.Empty() || rect.Empty() {
+		return
+	}
+
+	scale := min(float64(rect.Dx())/float64(bounds.Dx()), float64(rect.Dy())/float64(bounds.Dy()))
+	size := image.Pt(max(int(float64(bounds.Dx())*scale), 1), max(int(float64(bounds.Dy())*scale), 1))
+
+	scaled := scaleNearest(src, size)
+
+	offset := rect.Min.Add(image.Pt((rect.Dx()-size.X)/2, (rect.Dy()-size.Y)/2))
+	draw.Draw(dst, image.Rectangle{Min: offset, Max: offset.Add(size)}, scaled, image.Point{}, draw.Over)
+}
+
+func scaleNearest(src image.Image, size image.Point) *image.RGBA {
+	bounds := src.Bounds()
+	scaled := image.NewRGBA(image.Rectangle{Max: size})
+
+	for y := range size.Y {
+		sy := bounds.Min.Y + y*bounds.Dy()/size.Y
+		for x := range size.X {
+			sx := bounds.Min.X + x*bounds.Dx()/size.X
+			scaled.Set(x, y, src.At(sx, sy))
+		}
+	}
+
+	return scaled
+}
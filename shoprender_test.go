@@ -0,0 +1,144 @@
+package fortniteapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPNG(t *testing.T, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+type recordedText struct {
+	text  string
+	style TextStyle
+}
+
+func Test_ShopRenderer_RenderPNG(t *testing.T) {
+	t.Parallel()
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+
+	fixtures := map[string][]byte{
+		"render.png": testPNG(t, red),
+		"vbuck.png":  testPNG(t, color.RGBA{B: 0xff, A: 0xff}),
+	}
+
+	fetcher := ImageFetcherFunc(func(_ context.Context, url string) ([]byte, error) {
+		if data, ok := fixtures[url]; ok {
+			return data, nil
+		}
+
+		return nil, errors.New("not found")
+	})
+
+	var (
+		mu     sync.Mutex
+		texts  []recordedText
+		failed []string
+	)
+
+	renderer := NewShopRenderer(ShopRendererOptions{
+		Fetcher: fetcher,
+		Text: TextDrawerFunc(func(_ draw.Image, text string, _ image.Rectangle, style TextStyle) {
+			texts = append(texts, recordedText{text, style})
+		}),
+		Template: ShopTemplate{UnitWidth: 100, UnitHeight: 100, Columns: 2, Gap: 10, Padding: 20, HeaderHeight: 30, InfoHeight: 20},
+		OnError: func(url string, _ error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			failed = append(failed, url)
+		},
+	})
+
+	featured := ShopItemLayout{
+		ID:   "featured",
+		Name: "Featured",
+		StringMetadata: []ShopItemLayoutStringMetadata{
+			{Key: "BackgroundColorA", Value: "336699"},
+			{Key: "TextColor", Value: "ffcc00"},
+			{Key: "TextBackgroundColor", Value: "000000"},
+		},
+		TextMetadata: []ShopItemLayoutTextMetadata{{Key: "SubHeader", Value: "Ends soon"}},
+	}
+
+	shop := &ShopResponse{
+		VBuckIcon: "vbuck.png",
+		Entries: []ShopItem{
+			{
+				FinalPrice:      800,
+				RegularPrice:    1200,
+				Layout:          featured,
+				Banner:          ShopItemBanner{Value: "New!", Intensity: "High"},
+				Bundle:          ShopItemBundle{Name: "Raider Bundle"},
+				NewDisplayAsset: ShopItemNewDisplayAsset{RenderImages: []ShopItemNewDisplayAssetRenderImage{{Image: "render.png"}}},
+			},
+			{
+				FinalPrice:   500,
+				RegularPrice: 500,
+				Layout:       featured,
+				Colors:       ShopItemColors{Color1: "00ff00", Color2: "#00ff00ff"},
+				BRItems:      []BRCosmetic{{Name: "Peely", Images: BRCosmeticImages{Icon: "missing.png"}}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderer.RenderPNG(testCtx, shop, &buf))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	// 20 + 100 + 10 + 100 + 20 wide, 20 + 30 + 100 + 20 high.
+	assert.Equal(t, image.Rect(0, 0, 250, 170), img.Bounds())
+
+	assertColor := func(x, y int, expected color.RGBA) {
+		t.Helper()
+
+		r, g, b, a := img.At(x, y).RGBA()
+		assert.Equal(t, expected, color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)})
+	}
+
+	assertColor(5, 5, color.RGBA{R: 0x10, G: 0x18, B: 0x30, A: 0xff})
+	assertColor(5, 100, color.RGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff})
+	assertColor(100, 30, color.RGBA{A: 0xff})
+	assertColor(70, 100, red)
+	assertColor(200, 100, color.RGBA{G: 0xff, A: 0xff})
+
+	assert.Equal(t, []string{"missing.png"}, failed)
+
+	var drawn []string
+	for _, text := range texts {
+		drawn = append(drawn, text.text)
+	}
+
+	assert.Equal(t, []string{"Featured", "Ends soon", "Raider Bundle", "800", "1200", "New!", "Peely", "500"}, drawn)
+	assert.Equal(t, color.NRGBA{R: 0xff, G: 0xcc, A: 0xff}, texts[0].style.Color)
+	assert.True(t, texts[4].style.Strikethrough)
+}
+
+func Test_ShopRenderer_RequiresTextDrawer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewShopRenderer(ShopRendererOptions{}).Render(testCtx, &ShopResponse{})
+	require.ErrorIs(t, err, ErrNoTextDrawer)
+}
@@ -0,0 +1,278 @@
+package fortniteapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const shopResetInterval = 24 * time.Hour
+
+type ShopEventType string
+
+const (
+	ShopOfferAdded   ShopEventType = "offerAdded"
+	ShopOfferRemoved ShopEventType = "offerRemoved"
+	ShopPriceChanged ShopEventType = "priceChanged"
+	ShopItemReturned ShopEventType = "itemReturned"
+)
+
+type ShopEvent struct {
+	Type ShopEventType
+
+	// Offer is the current offer, or the last seen one for removed offers.
+	Offer ShopItem
+
+	// Previous is the offer before a price change.
+	Previous ShopItem
+
+	// CosmeticID and DaysAway are set for returning items.
+	CosmeticID string
+	DaysAway   int
+}
+
+type ShopWatcherOptions struct {
+	Language      Language
+	ResponseFlags ResponseFlag
+
+	// ResetDelay is how long after the daily reset at 00:00 UTC to poll.
+	//
+	// Default: 1 minute
+	ResetDelay time.Duration
+
+	// RetryInterval and RetryWindow control polling after a reset while the
+	// shop hash is still unchanged. RetryWindow is counted from the reset
+	// and is extended by ResetDelay when it would end before the first poll.
+	// A failed poll is retried after RetryInterval at any time of day.
+	//
+	// Default: 1 minute and 30 minutes
+	RetryInterval time.Duration
+	RetryWindow   time.Duration
+
+	// ReturnAfterDays is the minimum absence before a ShopItemReturned event.
+	//
+	// Default: 30
+	ReturnAfterDays int
+
+	Clock   Clock
+	OnError func(error)
+}
+
+// ShopWatcher polls the item shop around the daily reset and reports
+// what changed since the previous rotation.
+type ShopWatcher struct {
+	client  *Client
+	options ShopWatcherOptions
+
+	// pollMu serializes polls, so mu is only held to read and swap the
+	// previous shop and Shop doesn't wait for the network.
+	pollMu sync.Mutex
+
+	mu       sync.Mutex
+	previous *ShopResponse
+}
+
+func NewShopWatcher(client *Client, options ShopWatcherOptions) *ShopWatcher {
+	if options.ResetDelay <= 0 {
+		options.ResetDelay = time.Minute
+	}
+
+	if options.RetryInterval <= 0 {
+		options.RetryInterval = time.Minute
+	}
+
+	if options.RetryWindow <= 0 {
+		options.RetryWindow = 30 * time.Minute
+	}
+
+	if options.RetryWindow <= options.ResetDelay {
+		options.RetryWindow += options.ResetDelay
+	}
+
+	if options.ReturnAfterDays <= 0 {
+		options.ReturnAfterDays = 30
+	}
+
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	return &ShopWatcher{
+		client:  client,
+		options: options,
+	}
+}
+
+// Load seeds the watcher with a previously seen shop, so the next Poll
+// only reports changes relative to it.
+func (w *ShopWatcher) Load(shop *ShopResponse) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	w.setShop(shop)
+}
+
+// Shop returns the last seen shop, or nil before the first Poll or Load.
+func (w *ShopWatcher) Shop() *ShopResponse {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.previous
+}
+
+func (w *ShopWatcher) setShop(shop *ShopResponse) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.previous = shop
+}
+
+// Poll fetches the shop once and returns the changes since the last call.
+// The boolean is false when the shop hash didn't change.
+func (w *ShopWatcher) Poll(ctx context.Context) ([]ShopEvent, bool, error) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	previous := w.Shop()
+
+	shop, err := w.client.GetShop(ctx, &ShopParams{
+		Language:      w.options.Language,
+		ResponseFlags: w.options.ResponseFlags | FlagIncludeShopHistory,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if previous != nil && previous.Hash == shop.Hash {
+		return nil, false, nil
+	}
+
+	events := w.diff(previous, shop)
+	w.setShop(shop)
+
+	return events, true, nil
+}
+
+func (w *ShopWatcher) Run(ctx context.Context, handler func(ShopEvent)) error {
+	var changed bool
+
+	poll := func(ctx context.Context) ([]ShopEvent, error) {
+		events, ok, err := w.Poll(ctx)
+		changed = ok
+
+		return events, err
+	}
+
+	next := func(err error) time.Duration {
+		return w.nextPoll(w.options.Clock.Now(), changed, err)
+	}
+
+	return runPolls(ctx, w.options.Clock, poll, next, w.options.OnError, handler)
+}
+
+func (w *ShopWatcher) nextPoll(now time.Time, changed bool, err error) time.Duration {
+	if err != nil {
+		return w.options.RetryInterval
+	}
+
+	lastReset := now.UTC().Truncate(shopResetInterval)
+	nextReset := lastReset.Add(shopResetInterval).Add(w.options.ResetDelay)
+
+	// A poll between the reset and ResetDelay saw the old shop.
+	if rotated := lastReset.Add(w.options.ResetDelay); now.Before(rotated) {
+		return rotated.Sub(now)
+	}
+
+	if !changed && now.Sub(lastReset) < w.options.RetryWindow {
+		return w.options.RetryInterval
+	}
+
+	return nextReset.Sub(now)
+}
+
+func (w *ShopWatcher) diff(previous, current *ShopResponse) []ShopEvent {
+	var events []ShopEvent
+
+	old := make(map[string]ShopItem)
+	if previous != nil {
+		for _, offer := range previous.Entries {
+			old[offer.OfferID] = offer
+		}
+	}
+
+	for _, offer := range current.Entries {
+		before, ok := old[offer.OfferID]
+		delete(old, offer.OfferID)
+
+		if !ok {
+			events = append(events, ShopEvent{Type: ShopOfferAdded, Offer: offer})
+			events = append(events, w.returningItems(current.Date, offer)...)
+
+			continue
+		}
+
+		if before.RegularPrice != offer.RegularPrice || before.FinalPrice != offer.FinalPrice {
+			events = append(events, ShopEvent{Type: ShopPriceChanged, Offer: offer, Previous: before})
+		}
+	}
+
+	if previous != nil {
+		for _, offer := range previous.Entries {
+			if _, ok := old[offer.OfferID]; ok {
+				events = append(events, ShopEvent{Type: ShopOfferRemoved, Offer: offer})
+			}
+		}
+	}
+
+	return events
+}
+
+func (w *ShopWatcher) returningItems(shopDate time.Time, offer ShopItem) []ShopEvent {
+	var events []ShopEvent
+
+	check := func(cosmeticID string, history []string) {
+		days, ok := daysSincePreviousAppearance(shopDate, history)
+		if ok && days >= w.options.ReturnAfterDays {
+			events = append(events, ShopEvent{Type: ShopItemReturned, Offer: offer, CosmeticID: cosmeticID, DaysAway: days})
+		}
+	}
+
+	for _, item := range offer.BRItems {
+		check(item.ID, item.ShopHistory)
+	}
+
+	for _, item := range offer.Tracks {
+		check(item.ID, item.ShopHistory)
+	}
+
+	for _, item := range offer.Instruments {
+		check(item.ID, item.ShopHistory)
+	}
+
+	for _, item := range offer.Cars {
+		check(item.ID, item.ShopHistory)
+	}
+
+	for _, item := range offer.LegoKits {
+		check(item.ID, item.ShopHistory)
+	}
+
+	return events
+}
+
+// daysSincePreviousAppearance returns the number of days between the shop
+// date and the latest appearance before that day.
+func daysSincePreviousAppearance(shopDate time.Time, history ShopHistory) (int, bool) {
+	var latest time.Time
+	for _, appearance := range history.Times() {
+		if daysBetween(appearance, shopDate) > 0 {
+			latest = appearance
+		}
+	}
+
+	if latest.IsZero() {
+		return 0, false
+	}
+
+	return daysBetween(latest, shopDate), true
+}
@@ -0,0 +1,216 @@
+package fortniteapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ShopWatcher_Poll(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	server := &fakeServer[ShopResponse]{}
+	server.set(ShopResponse{
+		Hash: "h1",
+		Date: day,
+		Entries: []ShopItem{
+			{OfferID: "kept", RegularPrice: 1500, FinalPrice: 1500},
+			{OfferID: "removed", RegularPrice: 800, FinalPrice: 800},
+		},
+	})
+
+	client := newTestClient(t, server.handle(t))
+	watcher := NewShopWatcher(client, ShopWatcherOptions{})
+
+	events, changed, err := watcher.Poll(testCtx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Len(t, events, 2)
+
+	_, changed, err = watcher.Poll(testCtx)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	server.set(ShopResponse{
+		Hash: "h2",
+		Date: day.Add(24 * time.Hour),
+		Entries: []ShopItem{
+			{OfferID: "kept", RegularPrice: 1500, FinalPrice: 1200},
+			{OfferID: "new", BRItems: []BRCosmetic{{
+				ID:          testCosmeticID1,
+				ShopHistory: []string{"2024-12-01T00:00:00Z", "2025-01-01T00:00:00Z", "2025-03-11T00:00:00Z"},
+			}}},
+		},
+	})
+
+	events, changed, err = watcher.Poll(testCtx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.Len(t, events, 4)
+
+	assert.Equal(t, ShopPriceChanged, events[0].Type)
+	assert.Equal(t, 1500, events[0].Previous.FinalPrice)
+	assert.Equal(t, 1200, events[0].Offer.FinalPrice)
+
+	assert.Equal(t, ShopOfferAdded, events[1].Type)
+	assert.Equal(t, "new", events[1].Offer.OfferID)
+
+	assert.Equal(t, ShopItemReturned, events[2].Type)
+	assert.Equal(t, testCosmeticID1, events[2].CosmeticID)
+	assert.Equal(t, 69, events[2].DaysAway)
+
+	assert.Equal(t, ShopOfferRemoved, events[3].Type)
+	assert.Equal(t, "removed", events[3].Offer.OfferID)
+}
+
+func Test_ShopWatcher_RunFollowsReset(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	server := &fakeServer[ShopResponse]{}
+	server.set(ShopResponse{Hash: "h1"})
+
+	client := newTestClient(t, server.handle(t))
+	watcher := NewShopWatcher(client, ShopWatcherOptions{Clock: clock})
+
+	ctx, cancel := context.WithCancel(testCtx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx, func(ShopEvent) {})
+	}()
+
+	assert.Equal(t, 12*time.Hour+time.Minute, clock.advance(t, nil))
+	assert.Equal(t, time.Minute, clock.advance(t, func() {
+		server.set(ShopResponse{Hash: "h2"})
+	}))
+	assert.Equal(t, 24*time.Hour-time.Minute, clock.advance(t, nil))
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func Test_ShopWatcher_RunStartsBeforeResetDelay(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Date(2025, 3, 10, 0, 0, 20, 0, time.UTC))
+
+	server := &fakeServer[ShopResponse]{}
+	server.set(ShopResponse{Hash: "h1"})
+
+	client := newTestClient(t, server.handle(t))
+	watcher := NewShopWatcher(client, ShopWatcherOptions{Clock: clock})
+
+	ctx, cancel := context.WithCancel(testCtx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx, func(ShopEvent) {})
+	}()
+
+	// The first poll got the shop from before the reset, so the watcher
+	// polls again once ResetDelay has passed instead of waiting a day.
+	assert.Equal(t, 40*time.Second, clock.advance(t, nil))
+	assert.Equal(t, time.Minute, clock.advance(t, func() {
+		server.set(ShopResponse{Hash: "h2"})
+	}))
+	assert.Equal(t, 24*time.Hour-time.Minute, clock.advance(t, nil))
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func Test_ShopWatcher_RunRetriesOnError(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	server := &fakeServer[ShopResponse]{}
+	server.set(ShopResponse{Hash: "h1"})
+
+	var failing atomic.Bool
+	failing.Store(true)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		server.handle(t)(w, r)
+	})
+
+	var errs atomic.Int32
+	watcher := NewShopWatcher(client, ShopWatcherOptions{
+		Clock:   clock,
+		OnError: func(error) { errs.Add(1) },
+	})
+
+	ctx, cancel := context.WithCancel(testCtx)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx, func(ShopEvent) {})
+	}()
+
+	// A failure at midday is retried instead of waiting for the next reset.
+	assert.Equal(t, time.Minute, clock.advance(t, func() {
+		failing.Store(false)
+	}))
+	assert.Equal(t, 12*time.Hour, clock.advance(t, nil))
+	assert.Equal(t, int32(1), errs.Load())
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func Test_ShopWatcher_ShopDuringPoll(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		writeTestData(t, w, ShopResponse{Hash: "h2"})
+	})
+
+	watcher := NewShopWatcher(client, ShopWatcherOptions{})
+	watcher.Load(&ShopResponse{Hash: "h1"})
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := watcher.Poll(testCtx)
+		done <- err
+	}()
+
+	// Shop returns while the request is still in flight.
+	<-started
+	assert.Equal(t, "h1", watcher.Shop().Hash)
+	close(release)
+
+	require.NoError(t, <-done)
+	assert.Equal(t, "h2", watcher.Shop().Hash)
+}
+
+func Test_ShopWatcher_RetryWindowCoversResetDelay(t *testing.T) {
+	t.Parallel()
+
+	watcher := NewShopWatcher(nil, ShopWatcherOptions{ResetDelay: time.Hour})
+
+	// The first poll after the reset still retries a stale shop.
+	now := time.Date(2025, 3, 10, 1, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Minute, watcher.nextPoll(now, false, nil))
+	assert.Equal(t, 24*time.Hour, watcher.nextPoll(now, true, nil))
+}
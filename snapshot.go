@@ -0,0 +1,280 @@
+package fortniteapi
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	snapshotCreatorCodesName = "creatorcodes.json"
+	snapshotStatsDir         = "stats"
+	snapshotStatsIndexName   = "index.json"
+)
+
+// snapshotEndpoints are the endpoints recorded for each language and set of
+// response flags, by file name.
+var snapshotEndpoints = []struct {
+	name string
+	path string
+}{
+	{"banners", "/v1/banners"},
+	{"bannercolors", "/v1/banners/colors"},
+	{"cosmetics", "/v2/cosmetics"},
+	{"cosmetics-new", "/v2/cosmetics/new"},
+	{"cosmetics-br", "/v2/cosmetics/br"},
+	{"cosmetics-tracks", "/v2/cosmetics/tracks"},
+	{"cosmetics-instruments", "/v2/cosmetics/instruments"},
+	{"cosmetics-cars", "/v2/cosmetics/cars"},
+	{"cosmetics-lego", "/v2/cosmetics/lego"},
+	{"cosmetics-legokits", "/v2/cosmetics/lego/kits"},
+	{"cosmetics-beans", "/v2/cosmetics/beans"},
+	{"map", "/v1/map"},
+	{"news", "/v2/news"},
+	{"news-br", "/v2/news/br"},
+	{"news-stw", "/v2/news/stw"},
+	{"news-creative", "/v2/news/creative"},
+	{"playlists", "/v1/playlists"},
+	{"shop", "/v2/shop"},
+}
+
+// snapshotKeyFormats are the AES key formats, recorded once each.
+var snapshotKeyFormats = []string{"hex", "aes"}
+
+type SnapshotOptions struct {
+	// Default: the client's language, or English
+	Languages []Language
+
+	// ResponseFlags are the flag combinations to record. The handler strips
+	// the fields of missing flags from the closest recorded combination, so
+	// recording FlagAll is enough for every combination to work.
+	//
+	// Default: 0 and FlagAll
+	ResponseFlags []ResponseFlag
+
+	// CreatorCodes to record.
+	CreatorCodes []string
+
+	// Stats to record with the default time window. They need an API key.
+	Stats []BRStatsLookup
+}
+
+// snapshotStatsIndex maps stats lookups to files in the stats directory.
+// Names are keyed by account type and lowercase name.
+type snapshotStatsIndex struct {
+	Names map[string]string `json:"names"`
+	IDs   map[string]string `json:"ids"`
+}
+
+// RecordSnapshot saves the responses of every endpoint the client supports
+// into dir, to be served by a SnapshotHandler. Cosmetics by ID, playlists by
+// ID and searches are answered from the recorded lists, so they aren't
+// recorded on their own.
+//
+// Responses are saved as they are, errors included, except for rate limits
+// and server errors, which stop the recording.
+func RecordSnapshot(ctx context.Context, client *Client, dir string, options SnapshotOptions) error {
+	if len(options.Languages) == 0 {
+		options.Languages = []Language{cmp.Or(client.language, LanguageEnglish)}
+	}
+
+	if len(options.ResponseFlags) == 0 {
+		options.ResponseFlags = []ResponseFlag{0, FlagAll}
+	}
+
+	for _, language := range options.Languages {
+		if err := os.MkdirAll(filepath.Join(dir, string(language)), 0o755); err != nil {
+			return fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+
+		for _, flags := range options.ResponseFlags {
+			query := url.Values{
+				"language":      {string(language)},
+				"responseFlags": {strconv.FormatUint(uint64(flags), 10)},
+			}
+
+			for _, endpoint := range snapshotEndpoints {
+				raw, err := recordSnapshotResponse(ctx, client, http.MethodGet, endpoint.path, query)
+				if err != nil {
+					return err
+				}
+
+				if err := writeSnapshotFile(filepath.Join(dir, string(language), snapshotFileName(endpoint.name, flags)), raw); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, format := range snapshotKeyFormats {
+		raw, err := recordSnapshotResponse(ctx, client, http.MethodGet, "/v2/aes", url.Values{"keyFormat": {format}})
+		if err != nil {
+			return err
+		}
+
+		if err := writeSnapshotFile(filepath.Join(dir, snapshotAESFileName(format)), raw); err != nil {
+			return err
+		}
+	}
+
+	if len(options.CreatorCodes) > 0 {
+		codes := make(map[string]json.RawMessage, len(options.CreatorCodes))
+
+		for _, name := range options.CreatorCodes {
+			raw, err := recordSnapshotResponse(ctx, client, http.MethodGet, "/v2/creatorcode", url.Values{"name": {name}})
+			if err != nil {
+				return err
+			}
+
+			codes[strings.ToLower(name)] = raw
+		}
+
+		if err := writeSnapshotJSON(filepath.Join(dir, snapshotCreatorCodesName), codes); err != nil {
+			return err
+		}
+	}
+
+	if len(options.Stats) > 0 {
+		return recordSnapshotStats(ctx, client, filepath.Join(dir, snapshotStatsDir), options.Stats)
+	}
+
+	return nil
+}
+
+func recordSnapshotStats(ctx context.Context, client *Client, dir string, lookups []BRStatsLookup) error {
+	if err := client.checkAPIKey(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	index := snapshotStatsIndex{
+		Names: make(map[string]string),
+		IDs:   make(map[string]string),
+	}
+
+	for _, lookup := range lookups {
+		var (
+			path  = "/v2/stats/br/v2/" + lookup.ID
+			query = url.Values{}
+		)
+
+		if lookup.ID == "" {
+			path = "/v2/stats/br/v2"
+			query.Set("name", lookup.Name)
+			query.Set("accountType", cmp.Or(lookup.AccountType, defaultAccountType))
+		}
+
+		raw, err := recordSnapshotResponse(ctx, client, http.MethodGet, path, query)
+		if err != nil {
+			return err
+		}
+
+		var envelope APIResponse[BRStatsResponse]
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			return fmt.Errorf("failed to decode stats response: %w", err)
+		}
+
+		// Accounts are stored by ID. Lookups that failed are stored under
+		// the checksum of what was looked up.
+		name := envelope.Data.Account.ID
+		if name == "" {
+			name = checksum([]byte(lookup.ID + "/" + accountKey(lookup.AccountType, lookup.Name)))[:16]
+		}
+
+		name += ".json"
+
+		if err := writeSnapshotFile(filepath.Join(dir, name), raw); err != nil {
+			return err
+		}
+
+		if lookup.ID != "" {
+			index.IDs[strings.ToLower(lookup.ID)] = name
+		} else {
+			index.Names[accountKey(lookup.AccountType, lookup.Name)] = name
+		}
+
+		// The account name is the Epic name, whatever the lookup was by.
+		if id := envelope.Data.Account.ID; id != "" {
+			index.IDs[strings.ToLower(id)] = name
+			index.Names[accountKey(defaultAccountType, envelope.Data.Account.Name)] = name
+		}
+	}
+
+	return writeSnapshotJSON(filepath.Join(dir, snapshotStatsIndexName), index)
+}
+
+// recordSnapshotResponse returns the raw body of a request, bypassing the
+// client's cache.
+func recordSnapshotResponse(ctx context.Context, client *Client, method, path string, query url.Values) (json.RawMessage, error) {
+	fullURL, err := client.buildURL(path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.do(ctx, method, fullURL, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close() //nolint:errcheck
+
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= http.StatusInternalServerError {
+		return nil, newAPIError(method, path, response, raw)
+	}
+
+	if !json.Valid(raw) {
+		return nil, &DecodeError{
+			Status:      response.StatusCode,
+			ContentType: response.Header.Get("Content-Type"),
+			Body:        bodySnippet(raw),
+			Err:         fmt.Errorf("invalid JSON from %s", path),
+		}
+	}
+
+	return raw, nil
+}
+
+func snapshotFileName(name string, flags ResponseFlag) string {
+	if flags == 0 {
+		return name + ".json"
+	}
+
+	return name + ".flags-" + strconv.FormatUint(uint64(flags), 10) + ".json"
+}
+
+func snapshotAESFileName(format string) string {
+	return "aes-" + format + ".json"
+}
+
+func writeSnapshotFile(path string, raw []byte) error {
+	if err := writeFileAtomic(path, raw); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+
+	return nil
+}
+
+func writeSnapshotJSON(path string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot file: %w", err)
+	}
+
+	return writeSnapshotFile(path, raw)
+}
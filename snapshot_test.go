@@ -0,0 +1,209 @@
+package fortniteapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIHandler answers every snapshot endpoint, with names in the
+// requested language and the fields of the requested response flags.
+func fakeAPIHandler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	stats := newFakeStatsServer()
+	stats.set(testStats("account-1", "Player", BRStatsData{Wins: 3}))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		language := query.Get("language")
+		flags, _ := strconv.ParseUint(query.Get("responseFlags"), 10, 32)
+
+		cosmetic := func(id, name, added string) BRCosmetic {
+			cosmetic := BRCosmetic{ID: id, Name: name + " " + language, Added: added}
+			if ResponseFlag(flags)&FlagIncludePaths != 0 {
+				cosmetic.Path = "Path/" + id
+				cosmetic.DefinitionPath = "Definition/" + id
+				cosmetic.DisplayAssetPath = "DisplayAsset/" + id
+				cosmetic.ItemPreviewHeroPath = "ItemPreviewHero/" + id
+			}
+
+			if ResponseFlag(flags)&FlagIncludeGameplayTags != 0 {
+				cosmetic.GameplayTags = []string{"Cosmetics.Source." + id}
+			}
+
+			if ResponseFlag(flags)&FlagIncludeShopHistory != 0 {
+				cosmetic.ShopHistory = ShopHistory{"2024-01-01T00:00:00Z"}
+			}
+
+			return cosmetic
+		}
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v2/stats/"):
+			stats.handle(t)(w, r)
+		case r.URL.Path == "/v2/cosmetics/br":
+			writeTestData(t, w, []BRCosmetic{
+				cosmetic("CID_1", "Peely", "2020-01-01T00:00:00Z"),
+				cosmetic("CID_2", "Jonesy", "2021-01-01T00:00:00Z"),
+			})
+		case r.URL.Path == "/v1/playlists":
+			writeTestData(t, w, []Playlist{{ID: "Playlist_Solo", Name: "Solo " + language}})
+		case r.URL.Path == "/v2/aes":
+			writeTestData(t, w, AESKeyResponse{MainKey: query.Get("keyFormat")})
+		case r.URL.Path == "/v2/creatorcode":
+			writeTestData(t, w, CreatorCodeResponse{Code: query.Get("name")})
+		case r.URL.Path == "/v2/news/creative":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":404,"error":"creative news are not available"}`)) //nolint:errcheck
+		case r.URL.Path == "/v1/banners" || r.URL.Path == "/v1/banners/colors" || strings.HasPrefix(r.URL.Path, "/v2/cosmetics/"):
+			writeTestData(t, w, []any{})
+		default:
+			writeTestData(t, w, map[string]string{"language": language})
+		}
+	}
+}
+
+func Test_Snapshot_RecordAndServe(t *testing.T) {
+	t.Parallel()
+
+	upstream := httptest.NewServer(fakeAPIHandler(t))
+	t.Cleanup(upstream.Close)
+
+	dir := t.TempDir()
+	recorder := NewClient(LanguageEnglish, "test-key", WithBaseURL(upstream.URL))
+
+	err := RecordSnapshot(testCtx, recorder, dir, SnapshotOptions{
+		Languages:    []Language{LanguageEnglish, LanguageTurkish},
+		CreatorCodes: []string{"Ninja"},
+		Stats:        BRStatsLookupsByName("Player"),
+	})
+	require.NoError(t, err)
+
+	handler, err := NewSnapshotHandler(dir, SnapshotHandlerOptions{})
+	require.NoError(t, err)
+
+	replay := httptest.NewServer(handler)
+	t.Cleanup(replay.Close)
+
+	client := NewClient(LanguageEnglish, "test-key", WithBaseURL(replay.URL))
+
+	want, err := recorder.GetBRCosmeticsList(testCtx, nil)
+	require.NoError(t, err)
+
+	got, err := client.GetBRCosmeticsList(testCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = client.GetBRMap(testCtx, &BRMapParams{Language: LanguageTurkish})
+	require.NoError(t, err)
+
+	cosmetic, err := client.GetBRCosmeticByID(testCtx, "cid_2", &BRCosmeticByIDParams{Language: LanguageTurkish})
+	require.NoError(t, err)
+	assert.Equal(t, "Jonesy tr", cosmetic.Name)
+
+	_, err = client.GetBRCosmeticByID(testCtx, "CID_3", nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	found, err := client.SearchBRCosmetic(testCtx, &SearchBRCosmeticParams{Name: "peely en"})
+	require.NoError(t, err)
+	assert.Equal(t, "CID_1", found.ID)
+
+	// The search runs on the searchLanguage names and answers in language.
+	all, err := client.SearchBRCosmetics(testCtx, &SearchBRCosmeticsParams{
+		SearchLanguage: LanguageTurkish,
+		Name:           "tr",
+		MatchMethod:    MatchMethodEnds,
+		AddedSince:     1577923200,
+	})
+	require.NoError(t, err)
+	require.Len(t, *all, 1)
+	assert.Equal(t, "Jonesy en", (*all)[0].Name)
+
+	byIDs, err := client.SearchBRCosmeticsByIDs(testCtx, []string{"CID_2", "CID_1"}, nil)
+	require.NoError(t, err)
+	require.Len(t, *byIDs, 2)
+	assert.Equal(t, "CID_2", (*byIDs)[0].ID)
+
+	playlist, err := client.GetPlaylistByID(testCtx, "Playlist_Solo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Solo en", playlist.Name)
+
+	aes, err := client.GetAESKey(testCtx, &AESKeyParams{KeyFormat: "aes"})
+	require.NoError(t, err)
+	assert.Equal(t, "aes", aes.MainKey)
+
+	code, err := client.GetCreatorCode(testCtx, "ninja", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Ninja", code.Code)
+
+	stats, err := client.GetBRStatsByName(testCtx, "player", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "account-1", stats.Account.ID)
+
+	stats, err = client.GetBRStatsByID(testCtx, "account-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Stats.All.Overall.Wins)
+
+	_, err = client.GetCreativeNews(testCtx, nil)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.GetShop(testCtx, &ShopParams{Language: LanguageGerman})
+	require.ErrorIs(t, err, ErrBadRequest)
+}
+
+func Test_Snapshot_ConsoleStatsLookup(t *testing.T) {
+	t.Parallel()
+
+	api := fakeAPIHandler(t)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/stats/br/v2" {
+			api(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		if query.Get("accountType") != "psn" || query.Get("name") != "PlayerPSN" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":404,"error":"the requested account does not exist"}`)) //nolint:errcheck
+
+			return
+		}
+
+		writeTestData(t, w, testStats("account-2", "Player", BRStatsData{Wins: 7}))
+	}))
+	t.Cleanup(upstream.Close)
+
+	dir := t.TempDir()
+	recorder := NewClient(LanguageEnglish, "test-key", WithBaseURL(upstream.URL))
+
+	err := RecordSnapshot(testCtx, recorder, dir, SnapshotOptions{
+		Stats: []BRStatsLookup{{Name: "PlayerPSN", AccountType: "psn"}},
+	})
+	require.NoError(t, err)
+
+	handler, err := NewSnapshotHandler(dir, SnapshotHandlerOptions{})
+	require.NoError(t, err)
+
+	replay := httptest.NewServer(handler)
+	t.Cleanup(replay.Close)
+
+	client := NewClient(LanguageEnglish, "test-key", WithBaseURL(replay.URL))
+
+	stats, err := client.GetBRStatsByName(testCtx, "PlayerPSN", &BRStatsByNameParams{AccountType: "psn"})
+	require.NoError(t, err)
+	assert.Equal(t, "account-2", stats.Account.ID)
+
+	// The response has the Epic name, which is looked up as an epic account.
+	stats, err = client.GetBRStatsByName(testCtx, "player", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "account-2", stats.Account.ID)
+
+	_, err = client.GetBRStatsByName(testCtx, "Player", &BRStatsByNameParams{AccountType: "psn"})
+	require.ErrorIs(t, err, ErrNotFound)
+}
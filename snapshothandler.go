@@ -0,0 +1,629 @@
+package fortniteapi
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	snapshotSearchPath    = "/v2/cosmetics/br/search"
+	snapshotSearchAllPath = "/v2/cosmetics/br/search/all"
+	snapshotSearchIDsPath = "/v2/cosmetics/br/search/ids"
+	snapshotStatsPath     = "/v2/stats/br/v2"
+
+	snapshotCosmeticsBR = "cosmetics-br"
+	snapshotPlaylists   = "playlists"
+)
+
+// responseFlagFields are the fields each response flag adds.
+var responseFlagFields = []struct {
+	flag  ResponseFlag
+	field string
+}{
+	{FlagIncludePaths, "path"},
+	{FlagIncludePaths, "definitionPath"},
+	{FlagIncludePaths, "displayAssetPath"},
+	{FlagIncludePaths, "itemPreviewHeroPath"},
+	{FlagIncludeGameplayTags, "gameplayTags"},
+	{FlagIncludeShopHistory, "shopHistory"},
+}
+
+type SnapshotHandlerOptions struct {
+	// DefaultLanguage is used for requests without a language.
+	//
+	// Default: English
+	DefaultLanguage Language
+
+	// Clock is used by the date filters of searches.
+	Clock Clock
+}
+
+// SnapshotHandler serves a snapshot saved by RecordSnapshot on the paths of
+// the API, so a Client with WithBaseURL pointing at it works offline.
+//
+// The language and responseFlags parameters pick the recorded responses.
+// Languages that weren't recorded get the error the API gives for an
+// invalid language.
+// When the exact flags weren't recorded, the fields of the missing flags
+// are removed from a recording with more flags. Cosmetics and playlists by
+// ID and searches are answered from the recorded lists, searches with a
+// CosmeticIndex of the searchLanguage. Stats ignore timeWindow and image.
+type SnapshotHandler struct {
+	dir     string
+	options SnapshotHandlerOptions
+
+	paths map[string]string
+
+	// recorded lists the response flags recorded by language and endpoint.
+	recorded     map[Language]map[string][]ResponseFlag
+	creatorCodes map[string]json.RawMessage
+	stats        snapshotStatsIndex
+
+	mu      sync.Mutex
+	lists   map[snapshotListKey]*snapshotList
+	indexes map[Language]*CosmeticIndex
+}
+
+type snapshotListKey struct {
+	language Language
+	name     string
+	flags    ResponseFlag
+}
+
+type snapshotList struct {
+	items []json.RawMessage
+	byID  map[string]int
+}
+
+// NewSnapshotHandler reads the layout of the snapshot in dir. Responses are
+// read from disk as they are requested.
+func NewSnapshotHandler(dir string, options SnapshotHandlerOptions) (*SnapshotHandler, error) {
+	if options.DefaultLanguage == "" {
+		options.DefaultLanguage = LanguageEnglish
+	}
+
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	h := &SnapshotHandler{
+		dir:      dir,
+		options:  options,
+		paths:    make(map[string]string, len(snapshotEndpoints)),
+		recorded: make(map[Language]map[string][]ResponseFlag),
+		lists:    make(map[snapshotListKey]*snapshotList),
+		indexes:  make(map[Language]*CosmeticIndex),
+	}
+
+	for _, endpoint := range snapshotEndpoints {
+		h.paths[endpoint.path] = endpoint.name
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == snapshotStatsDir {
+			continue
+		}
+
+		if err := h.scanLanguage(Language(entry.Name())); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := readSnapshotJSON(filepath.Join(dir, snapshotCreatorCodesName), &h.creatorCodes); err != nil {
+		return nil, err
+	}
+
+	if err := readSnapshotJSON(filepath.Join(dir, snapshotStatsDir, snapshotStatsIndexName), &h.stats); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *SnapshotHandler) scanLanguage(language Language) error {
+	files, err := os.ReadDir(filepath.Join(h.dir, string(language)))
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	recorded := make(map[string][]ResponseFlag)
+
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+
+		var flags ResponseFlag
+
+		if endpoint, value, ok := strings.Cut(name, ".flags-"); ok {
+			parsed, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				continue
+			}
+
+			name, flags = endpoint, ResponseFlag(parsed)
+		}
+
+		recorded[name] = append(recorded[name], flags)
+	}
+
+	h.recorded[language] = recorded
+
+	return nil
+}
+
+func readSnapshotJSON(path string, v any) error {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to parse snapshot file: %w", err)
+	}
+
+	return nil
+}
+
+func (h *SnapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	if r.Method != http.MethodGet && (r.Method != http.MethodPost || path != snapshotSearchIDsPath) {
+		writeSnapshotError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	language := Language(cmp.Or(query.Get("language"), string(h.options.DefaultLanguage)))
+
+	var flags ResponseFlag
+	if value := query.Get("responseFlags"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			writeSnapshotError(w, http.StatusBadRequest, "invalid responseFlags: "+value)
+			return
+		}
+
+		flags = ResponseFlag(parsed)
+	}
+
+	// AES keys, creator codes and stats are recorded once for every language.
+	perLanguage := path != "/v2/aes" && path != "/v2/creatorcode" && !strings.HasPrefix(path, snapshotStatsPath)
+	if perLanguage && !h.checkLanguage(w, "language", language) {
+		return
+	}
+
+	switch {
+	case path == "/v2/aes":
+		format := cmp.Or(query.Get("keyFormat"), "hex")
+		if !slices.Contains(snapshotKeyFormats, format) {
+			writeSnapshotError(w, http.StatusBadRequest, "invalid keyFormat: "+format)
+			return
+		}
+
+		h.serveFile(w, filepath.Join(h.dir, snapshotAESFileName(format)))
+	case path == "/v2/creatorcode":
+		h.serveCreatorCode(w, query.Get("name"))
+	case path == snapshotStatsPath:
+		h.serveStats(w, h.stats.Names[accountKey(query.Get("accountType"), query.Get("name"))])
+	case strings.HasPrefix(path, snapshotStatsPath+"/"):
+		h.serveStats(w, h.stats.IDs[strings.ToLower(strings.TrimPrefix(path, snapshotStatsPath+"/"))])
+	case path == snapshotSearchPath, path == snapshotSearchAllPath:
+		h.serveSearch(w, query, language, flags, path == snapshotSearchAllPath)
+	case path == snapshotSearchIDsPath:
+		h.serveCosmeticsByIDs(w, r, language, flags)
+	case strings.HasPrefix(path, "/v2/cosmetics/br/"):
+		h.serveByID(w, language, snapshotCosmeticsBR, flags, strings.TrimPrefix(path, "/v2/cosmetics/br/"))
+	case strings.HasPrefix(path, "/v1/playlists/"):
+		h.serveByID(w, language, snapshotPlaylists, flags, strings.TrimPrefix(path, "/v1/playlists/"))
+	default:
+		name, ok := h.paths[path]
+		if !ok {
+			writeSnapshotError(w, http.StatusNotFound, "unknown endpoint: "+path)
+			return
+		}
+
+		h.serveEndpoint(w, language, name, flags)
+	}
+}
+
+// checkLanguage writes the API's error for an invalid parameter when the
+// language wasn't recorded, with the recorded languages as the valid values.
+func (h *SnapshotHandler) checkLanguage(w http.ResponseWriter, param string, language Language) bool {
+	if _, ok := h.recorded[language]; ok {
+		return true
+	}
+
+	valid := make([]string, 0, len(h.recorded))
+	for recorded := range h.recorded {
+		valid = append(valid, string(recorded))
+	}
+
+	slices.Sort(valid)
+
+	writeSnapshotError(w, http.StatusBadRequest, fmt.Sprintf("Invalid value for query parameter '%s'. Valid values: %s", param, strings.Join(valid, ", ")))
+
+	return false
+}
+
+func (h *SnapshotHandler) serveFile(w http.ResponseWriter, path string) {
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		writeSnapshotError(w, http.StatusNotFound, "not recorded in the snapshot")
+		return
+	} else if err != nil {
+		writeSnapshotError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSnapshotRaw(w, raw)
+}
+
+func (h *SnapshotHandler) serveCreatorCode(w http.ResponseWriter, name string) {
+	raw, ok := h.creatorCodes[strings.ToLower(name)]
+	if !ok {
+		writeSnapshotError(w, http.StatusNotFound, "creator code not recorded in the snapshot: "+name)
+		return
+	}
+
+	writeSnapshotRaw(w, raw)
+}
+
+func (h *SnapshotHandler) serveStats(w http.ResponseWriter, file string) {
+	if file == "" {
+		writeSnapshotError(w, http.StatusNotFound, "account not recorded in the snapshot")
+		return
+	}
+
+	h.serveFile(w, filepath.Join(h.dir, snapshotStatsDir, filepath.Base(file)))
+}
+
+func (h *SnapshotHandler) serveEndpoint(w http.ResponseWriter, language Language, name string, flags ResponseFlag) {
+	raw, err := h.read(language, name, flags)
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeSnapshotRaw(w, raw)
+}
+
+func (h *SnapshotHandler) serveByID(w http.ResponseWriter, language Language, name string, flags ResponseFlag, id string) {
+	list, err := h.list(language, name, flags)
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	i, ok := list.byID[strings.ToLower(id)]
+	if !ok {
+		writeSnapshotError(w, http.StatusNotFound, "no item found with the id: "+id)
+		return
+	}
+
+	writeSnapshotData(w, list.items[i])
+}
+
+func (h *SnapshotHandler) serveSearch(w http.ResponseWriter, query url.Values, language Language, flags ResponseFlag, all bool) {
+	var params SearchBRCosmeticsParams
+	if err := decodeSnapshotQuery(query, &params); err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if params.SearchLanguage != "" && !h.checkLanguage(w, "searchLanguage", params.SearchLanguage) {
+		return
+	}
+
+	index, err := h.cosmeticIndex(cmp.Or(params.SearchLanguage, language))
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	list, err := h.list(language, snapshotCosmeticsBR, flags)
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	results, err := index.Search(&params)
+	if err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var items []json.RawMessage
+	for _, cosmetic := range results {
+		if i, ok := list.byID[strings.ToLower(cosmetic.ID)]; ok {
+			items = append(items, list.items[i])
+		}
+	}
+
+	if len(items) == 0 {
+		writeSnapshotError(w, http.StatusNotFound, "no cosmetic matches the search parameters")
+		return
+	}
+
+	if !all {
+		writeSnapshotData(w, items[0])
+		return
+	}
+
+	writeSnapshotData(w, items)
+}
+
+func (h *SnapshotHandler) serveCosmeticsByIDs(w http.ResponseWriter, r *http.Request, language Language, flags ResponseFlag) {
+	var ids []string
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		writeSnapshotError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return
+	}
+
+	list, err := h.list(language, snapshotCosmeticsBR, flags)
+	if err != nil {
+		writeSnapshotError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	var items []json.RawMessage
+	for _, id := range ids {
+		if i, ok := list.byID[strings.ToLower(id)]; ok {
+			items = append(items, list.items[i])
+		}
+	}
+
+	if len(items) == 0 {
+		writeSnapshotError(w, http.StatusNotFound, "no cosmetic found with the given ids")
+		return
+	}
+
+	writeSnapshotData(w, items)
+}
+
+// read returns the body recorded for the endpoint, with the fields of flags
+// that weren't requested removed.
+func (h *SnapshotHandler) read(language Language, name string, flags ResponseFlag) ([]byte, error) {
+	recorded, ok := h.recorded[language]
+	if !ok {
+		return nil, fmt.Errorf("language not recorded in the snapshot: %s", language)
+	}
+
+	// The closest recording is the one with the fewest extra flags.
+	best, found := ResponseFlag(0), false
+	for _, candidate := range recorded[name] {
+		if candidate&flags != flags {
+			continue
+		}
+
+		if !found || bits.OnesCount32(uint32(candidate)) < bits.OnesCount32(uint32(best)) {
+			best, found = candidate, true
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%s not recorded in the snapshot for %s with responseFlags %d", name, language, flags)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(h.dir, string(language), snapshotFileName(name, best)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	if best == flags {
+		return raw, nil
+	}
+
+	return stripResponseFlags(raw, best&^flags)
+}
+
+// list returns the items of a recorded list endpoint.
+func (h *SnapshotHandler) list(language Language, name string, flags ResponseFlag) (*snapshotList, error) {
+	key := snapshotListKey{language: language, name: name, flags: flags}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if list, ok := h.lists[key]; ok {
+		return list, nil
+	}
+
+	raw, err := h.read(language, name, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope APIResponse[[]json.RawMessage]
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot file: %w", err)
+	}
+
+	list := &snapshotList{
+		items: envelope.Data,
+		byID:  make(map[string]int, len(envelope.Data)),
+	}
+
+	for i, item := range envelope.Data {
+		var identified struct {
+			ID string `json:"id"`
+		}
+
+		if err := json.Unmarshal(item, &identified); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot file: %w", err)
+		}
+
+		list.byID[strings.ToLower(identified.ID)] = i
+	}
+
+	h.lists[key] = list
+
+	return list, nil
+}
+
+// cosmeticIndex indexes the BR cosmetics recorded with the most flags, so
+// that the shop history is there for the date filters.
+func (h *SnapshotHandler) cosmeticIndex(language Language) (*CosmeticIndex, error) {
+	h.mu.Lock()
+	index, ok := h.indexes[language]
+	h.mu.Unlock()
+
+	if ok {
+		return index, nil
+	}
+
+	var flags ResponseFlag
+	for _, candidate := range h.recorded[language][snapshotCosmeticsBR] {
+		if bits.OnesCount32(uint32(candidate)) > bits.OnesCount32(uint32(flags)) {
+			flags = candidate
+		}
+	}
+
+	raw, err := h.read(language, snapshotCosmeticsBR, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope APIResponse[[]BRCosmetic]
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot file: %w", err)
+	}
+
+	index = NewCosmeticIndex(envelope.Data, CosmeticIndexOptions{Language: language, Clock: h.options.Clock})
+
+	h.mu.Lock()
+	h.indexes[language] = index
+	h.mu.Unlock()
+
+	return index, nil
+}
+
+// stripResponseFlags removes the fields added by flags from the data of a
+// response.
+func stripResponseFlags(raw []byte, flags ResponseFlag) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var envelope map[string]any
+	if err := decoder.Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot file: %w", err)
+	}
+
+	var fields []string
+	for _, field := range responseFlagFields {
+		if flags&field.flag != 0 {
+			fields = append(fields, field.field)
+		}
+	}
+
+	removeFields(envelope["data"], fields)
+
+	return json.Marshal(envelope)
+}
+
+func removeFields(v any, fields []string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, field := range fields {
+			delete(v, field)
+		}
+
+		for _, value := range v {
+			removeFields(value, fields)
+		}
+	case []any:
+		for _, value := range v {
+			removeFields(value, fields)
+		}
+	}
+}
+
+// decodeSnapshotQuery sets the fields of the struct out points to from
+// query, by their url tags.
+func decodeSnapshotQuery(query url.Values, out any) error {
+	v := reflect.ValueOf(out).Elem()
+
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("url"), ",")
+
+		value := query.Get(name)
+		if name == "" || value == "" {
+			continue
+		}
+
+		field := v.Field(i)
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Bool:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", name, value)
+			}
+
+			field.SetBool(parsed)
+		case reflect.Int, reflect.Int64:
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", name, value)
+			}
+
+			field.SetInt(parsed)
+		case reflect.Uint32:
+			parsed, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %s", name, value)
+			}
+
+			field.SetUint(parsed)
+		}
+	}
+
+	return nil
+}
+
+// writeSnapshotRaw writes a recorded body with the status of its envelope.
+func writeSnapshotRaw(w http.ResponseWriter, raw []byte) {
+	var envelope apiEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		writeSnapshotError(w, http.StatusInternalServerError, "failed to parse snapshot file: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(cmp.Or(envelope.Status, http.StatusOK))
+	w.Write(raw) //nolint:errcheck
+}
+
+func writeSnapshotData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(APIResponse[any]{Status: http.StatusOK, Data: data}) //nolint:errcheck
+}
+
+func writeSnapshotError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(apiEnvelope{Status: status, Error: message}) //nolint:errcheck
+}
@@ -0,0 +1,126 @@
+package fortniteapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getSnapshotBody(t *testing.T, url string) (int, string) {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(testCtx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	defer response.Body.Close() //nolint:errcheck
+
+	raw, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	return response.StatusCode, string(raw)
+}
+
+func Test_SnapshotHandler_MatchesRecordedFlags(t *testing.T) {
+	t.Parallel()
+
+	upstream := httptest.NewServer(fakeAPIHandler(t))
+	t.Cleanup(upstream.Close)
+
+	dir := t.TempDir()
+	recorder := NewClient(LanguageEnglish, "", WithBaseURL(upstream.URL))
+
+	err := RecordSnapshot(testCtx, recorder, dir, SnapshotOptions{ResponseFlags: []ResponseFlag{FlagAll}})
+	require.NoError(t, err)
+
+	handler, err := NewSnapshotHandler(dir, SnapshotHandlerOptions{})
+	require.NoError(t, err)
+
+	replay := httptest.NewServer(handler)
+	t.Cleanup(replay.Close)
+
+	// Only FlagAll is recorded, so every other combination is stripped.
+	for flags := ResponseFlag(0); flags <= FlagAll; flags++ {
+		query := "/v2/cosmetics/br?language=en&responseFlags=" + strconv.FormatUint(uint64(flags), 10)
+
+		wantStatus, want := getSnapshotBody(t, upstream.URL+query)
+		gotStatus, got := getSnapshotBody(t, replay.URL+query)
+
+		assert.Equal(t, wantStatus, gotStatus, "responseFlags %d", flags)
+		assert.JSONEq(t, want, got, "responseFlags %d", flags)
+	}
+}
+
+func Test_SnapshotHandler_UnrecordedLanguage(t *testing.T) {
+	t.Parallel()
+
+	upstream := httptest.NewServer(fakeAPIHandler(t))
+	t.Cleanup(upstream.Close)
+
+	dir := t.TempDir()
+	recorder := NewClient(LanguageEnglish, "", WithBaseURL(upstream.URL))
+
+	err := RecordSnapshot(testCtx, recorder, dir, SnapshotOptions{Languages: []Language{LanguageTurkish, LanguageEnglish}})
+	require.NoError(t, err)
+
+	handler, err := NewSnapshotHandler(dir, SnapshotHandlerOptions{})
+	require.NoError(t, err)
+
+	replay := httptest.NewServer(handler)
+	t.Cleanup(replay.Close)
+
+	client := NewClient(LanguageGerman, "", WithBaseURL(replay.URL))
+
+	_, err = client.GetBRCosmeticsList(testCtx, nil)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+	assert.Equal(t, "Invalid value for query parameter 'language'. Valid values: en, tr", apiErr.Message)
+
+	_, err = client.SearchBRCosmetics(testCtx, &SearchBRCosmeticsParams{Language: LanguageEnglish, Name: "peely"})
+	require.ErrorAs(t, err, &apiErr)
+	assert.Contains(t, apiErr.Message, "'searchLanguage'")
+
+	// AES keys aren't recorded per language.
+	_, err = client.GetAESKey(testCtx, nil)
+	require.NoError(t, err)
+}
+
+func Test_SnapshotHandler_ResponseFlags(t *testing.T) {
+	t.Parallel()
+
+	upstream := httptest.NewServer(fakeAPIHandler(t))
+	t.Cleanup(upstream.Close)
+
+	dir := t.TempDir()
+	recorder := NewClient(LanguageEnglish, "", WithBaseURL(upstream.URL))
+
+	err := RecordSnapshot(testCtx, recorder, dir, SnapshotOptions{ResponseFlags: []ResponseFlag{FlagAll}})
+	require.NoError(t, err)
+
+	handler, err := NewSnapshotHandler(dir, SnapshotHandlerOptions{})
+	require.NoError(t, err)
+
+	replay := httptest.NewServer(handler)
+	t.Cleanup(replay.Close)
+
+	client := NewClient(LanguageEnglish, "", WithBaseURL(replay.URL), WithDefaultResponseFlags(FlagIncludePaths))
+
+	cosmetics, err := client.GetBRCosmeticsList(testCtx, nil)
+	require.NoError(t, err)
+	require.Len(t, *cosmetics, 2)
+	assert.Equal(t, "Path/CID_1", (*cosmetics)[0].Path)
+	assert.Empty(t, (*cosmetics)[0].ShopHistory)
+
+	cosmetics, err = client.GetBRCosmeticsList(testCtx, &BRCosmeticsListParams{ResponseFlags: FlagAll})
+	require.NoError(t, err)
+	assert.Len(t, (*cosmetics)[0].ShopHistory, 1)
+}
@@ -0,0 +1,311 @@
+package fortniteapi
+
+import (
+	"fmt"
+	"math"
+)
+
+type StatsMetric string
+
+const (
+	MetricMatches          StatsMetric = "matches"
+	MetricWins             StatsMetric = "wins"
+	MetricKills            StatsMetric = "kills"
+	MetricKD               StatsMetric = "kd"
+	MetricWinRate          StatsMetric = "winRate"
+	MetricKillsPerMatch    StatsMetric = "killsPerMatch"
+	MetricKillsPerWin      StatsMetric = "killsPerWin"
+	MetricWinsPerHour      StatsMetric = "winsPerHour"
+	MetricScorePerMatch    StatsMetric = "scorePerMatch"
+	MetricTop10Rate        StatsMetric = "top10Rate"
+	MetricTop25Rate        StatsMetric = "top25Rate"
+	MetricHoursPlayed      StatsMetric = "hoursPlayed"
+	MetricAveragePlacement StatsMetric = "averagePlacement"
+)
+
+// StatsMetrics lists the metrics used in comparisons. Higher is better for
+// all of them except MetricAveragePlacement.
+var StatsMetrics = []StatsMetric{
+	MetricMatches,
+	MetricWins,
+	MetricKills,
+	MetricKD,
+	MetricWinRate,
+	MetricKillsPerMatch,
+	MetricKillsPerWin,
+	MetricWinsPerHour,
+	MetricScorePerMatch,
+	MetricTop10Rate,
+	MetricTop25Rate,
+	MetricHoursPlayed,
+	MetricAveragePlacement,
+}
+
+// LowerIsBetter reports whether smaller values of the metric are better.
+func (m StatsMetric) LowerIsBetter() bool {
+	return m == MetricAveragePlacement
+}
+
+func (d BRStatsData) Metric(metric StatsMetric) float64 {
+	switch metric {
+	case MetricMatches:
+		return float64(d.Matches)
+	case MetricWins:
+		return float64(d.Wins)
+	case MetricKills:
+		return float64(d.Kills)
+	case MetricKD:
+		return d.Recompute().KD
+	case MetricWinRate:
+		return d.Recompute().WinRate
+	case MetricKillsPerMatch:
+		return d.Recompute().KillsPerMatch
+	case MetricKillsPerWin:
+		return d.KillsPerWin()
+	case MetricWinsPerHour:
+		return d.WinsPerHour()
+	case MetricScorePerMatch:
+		return d.Recompute().ScorePerMatch
+	case MetricTop10Rate:
+		return d.TopRate(10)
+	case MetricTop25Rate:
+		return d.TopRate(25)
+	case MetricHoursPlayed:
+		return d.HoursPlayed()
+	case MetricAveragePlacement:
+		return d.AveragePlacement()
+	default:
+		return 0
+	}
+}
+
+// TopRate returns the percentage of matches finished in the top n, where
+// n is one of 3, 5, 6, 10, 12 or 25. Other values return 0.
+func (d BRStatsData) TopRate(n int) float64 {
+	var count int
+
+	switch n {
+	case 3:
+		count = d.Top3
+	case 5:
+		count = d.Top5
+	case 6:
+		count = d.Top6
+	case 10:
+		count = d.Top10
+	case 12:
+		count = d.Top12
+	case 25:
+		count = d.Top25
+	default:
+		return 0
+	}
+
+	return ratio(count, d.Matches) * 100
+}
+
+func (d BRStatsData) KillsPerWin() float64 {
+	return ratio(d.Kills, d.Wins)
+}
+
+func (d BRStatsData) HoursPlayed() float64 {
+	return float64(d.MinutesPlayed) / 60
+}
+
+func (d BRStatsData) WinsPerHour() float64 {
+	return ratio(d.Wins*60, d.MinutesPlayed)
+}
+
+func (d BRStatsData) MinutesPerMatch() float64 {
+	return ratio(d.MinutesPlayed, d.Matches)
+}
+
+// brLobbySize is the number of players in a regular battle royale match.
+const brLobbySize = 100
+
+// AveragePlacement estimates the average finishing position from the
+// players outlived per match, in a lobby of 100 players. Lower is better,
+// and it returns 0 when there are no matches.
+func (d BRStatsData) AveragePlacement() float64 {
+	if d.Matches == 0 {
+		return 0
+	}
+
+	return max(brLobbySize-ratio(d.PlayersOutlived, d.Matches), 1)
+}
+
+// Recompute returns a copy with every ratio computed from the counters.
+func (d BRStatsData) Recompute() BRStatsData {
+	d.recomputeRatios()
+	return d
+}
+
+type StatsMismatch struct {
+	Field    string
+	Reported float64
+	Computed float64
+}
+
+func (m StatsMismatch) String() string {
+	return fmt.Sprintf("%s: reported %.4f, computed %.4f", m.Field, m.Reported, m.Computed)
+}
+
+// Validate compares the reported ratios with ratios computed from the
+// counters and returns the ones that differ by more than tolerance. It
+// also reports counters that can't be right, such as more wins than
+// matches.
+func (d BRStatsData) Validate(tolerance float64) []StatsMismatch {
+	computed := d.Recompute()
+
+	checks := []StatsMismatch{
+		{"scorePerMin", d.ScorePerMin, computed.ScorePerMin},
+		{"scorePerMatch", d.ScorePerMatch, computed.ScorePerMatch},
+		{"killsPerMin", d.KillsPerMin, computed.KillsPerMin},
+		{"killsPerMatch", d.KillsPerMatch, computed.KillsPerMatch},
+		{"kd", d.KD, computed.KD},
+		{"winRate", d.WinRate, computed.WinRate},
+	}
+
+	var mismatches []StatsMismatch
+
+	for _, check := range checks {
+		if math.Abs(check.Reported-check.Computed) > tolerance {
+			mismatches = append(mismatches, check)
+		}
+	}
+
+	if d.Wins > d.Matches {
+		mismatches = append(mismatches, StatsMismatch{"wins", float64(d.Wins), float64(d.Matches)})
+	}
+
+	if d.Top25 > d.Matches {
+		mismatches = append(mismatches, StatsMismatch{"top25", float64(d.Top25), float64(d.Matches)})
+	}
+
+	return mismatches
+}
+
+// AggregateStats sums the counters of several entries and recomputes the
+// ratios. LastModified is the latest of the inputs.
+func AggregateStats(data ...BRStatsData) BRStatsData {
+	var total BRStatsData
+
+	for _, d := range data {
+		total.Score += d.Score
+		total.Wins += d.Wins
+		total.Top3 += d.Top3
+		total.Top5 += d.Top5
+		total.Top6 += d.Top6
+		total.Top10 += d.Top10
+		total.Top12 += d.Top12
+		total.Top25 += d.Top25
+		total.Kills += d.Kills
+		total.Deaths += d.Deaths
+		total.Matches += d.Matches
+		total.MinutesPlayed += d.MinutesPlayed
+		total.PlayersOutlived += d.PlayersOutlived
+
+		if d.LastModified > total.LastModified {
+			total.LastModified = d.LastModified
+		}
+	}
+
+	total.recomputeRatios()
+	return total
+}
+
+// OverallOrMerged returns Overall, or the aggregate of every mode when the
+// API didn't include Overall for this input type.
+func (m BRStatsModes) OverallOrMerged() BRStatsData {
+	if m.Overall.Matches > 0 {
+		return m.Overall
+	}
+
+	return AggregateStats(m.Solo, m.Duo, m.Trio, m.Squad, m.LTM)
+}
+
+type StatsComparisonRow struct {
+	Metric StatsMetric
+	Values []float64
+
+	// Best is the index of the best value, or -1 when all are equal. For
+	// metrics where lower is better, zero values mean there is no data and
+	// are never the best.
+	Best int
+}
+
+type StatsComparison struct {
+	Labels []string
+	Rows   []StatsComparisonRow
+}
+
+// Row returns the comparison row of a metric.
+func (c StatsComparison) Row(metric StatsMetric) (StatsComparisonRow, bool) {
+	for _, row := range c.Rows {
+		if row.Metric == metric {
+			return row, true
+		}
+	}
+
+	return StatsComparisonRow{}, false
+}
+
+// CompareStats lines up entries side by side for every metric in
+// StatsMetrics. Labels and data must have the same length.
+func CompareStats(labels []string, data []BRStatsData) StatsComparison {
+	comparison := StatsComparison{Labels: labels}
+
+	for _, metric := range StatsMetrics {
+		row := StatsComparisonRow{Metric: metric, Values: make([]float64, len(data)), Best: -1}
+
+		allEqual := true
+		for i, d := range data {
+			row.Values[i] = d.Metric(metric)
+
+			if row.Best == -1 || betterMetricValue(metric, row.Values[i], row.Values[row.Best]) {
+				row.Best = i
+			}
+
+			if row.Values[i] != row.Values[0] {
+				allEqual = false
+			}
+		}
+
+		if allEqual {
+			row.Best = -1
+		}
+
+		comparison.Rows = append(comparison.Rows, row)
+	}
+
+	return comparison
+}
+
+func betterMetricValue(metric StatsMetric, value, best float64) bool {
+	if !metric.LowerIsBetter() {
+		return value > best
+	}
+
+	return value != 0 && (best == 0 || value < best)
+}
+
+// ComparePlayers compares the overall stats of every input type combined.
+func ComparePlayers(a, b *BRStatsResponse) StatsComparison {
+	return CompareStats(
+		[]string{a.Account.Name, b.Account.Name},
+		[]BRStatsData{BRStatsModes(a.Stats.All).OverallOrMerged(), BRStatsModes(b.Stats.All).OverallOrMerged()},
+	)
+}
+
+// CompareInputs compares the overall stats of keyboard and mouse, gamepad
+// and touch.
+func (s BRStatsStats) CompareInputs() StatsComparison {
+	return CompareStats(
+		[]string{"keyboardMouse", "gamepad", "touch"},
+		[]BRStatsData{
+			BRStatsModes(s.KeyboardMouse).OverallOrMerged(),
+			BRStatsModes(s.Gamepad).OverallOrMerged(),
+			BRStatsModes(s.Touch).OverallOrMerged(),
+		},
+	)
+}
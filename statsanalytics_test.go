@@ -0,0 +1,126 @@
+package fortniteapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BRStatsData_DerivedRates(t *testing.T) {
+	t.Parallel()
+
+	data := BRStatsData{Wins: 5, Kills: 50, Matches: 50, Top10: 20, Top25: 30, MinutesPlayed: 600}
+
+	assert.InDelta(t, 40.0, data.TopRate(10), 0.0001)
+	assert.InDelta(t, 60.0, data.TopRate(25), 0.0001)
+	assert.Zero(t, data.TopRate(7))
+	assert.InDelta(t, 10.0, data.KillsPerWin(), 0.0001)
+	assert.InDelta(t, 0.5, data.WinsPerHour(), 0.0001)
+	assert.InDelta(t, 12.0, data.MinutesPerMatch(), 0.0001)
+}
+
+func Test_BRStatsData_Validate(t *testing.T) {
+	t.Parallel()
+
+	data := BRStatsData{Wins: 5, Kills: 20, Deaths: 10, Matches: 10, KD: 2, KillsPerMatch: 2, WinRate: 50}
+	assert.Empty(t, data.Validate(0.001))
+
+	data.KD = 3
+	data.Wins = 11
+
+	mismatches := data.Validate(0.001)
+	require.Len(t, mismatches, 3)
+	assert.Equal(t, "kd", mismatches[0].Field)
+	assert.Equal(t, "winRate", mismatches[1].Field)
+	assert.Equal(t, "wins", mismatches[2].Field)
+}
+
+func Test_AggregateStats(t *testing.T) {
+	t.Parallel()
+
+	total := AggregateStats(
+		BRStatsData{Wins: 1, Kills: 4, Deaths: 2, Matches: 4, LastModified: "2025-01-01T00:00:00Z"},
+		BRStatsData{Wins: 1, Kills: 6, Deaths: 3, Matches: 6, LastModified: "2025-02-01T00:00:00Z"},
+	)
+
+	assert.Equal(t, 2, total.Wins)
+	assert.Equal(t, 10, total.Matches)
+	assert.InDelta(t, 2.0, total.KD, 0.0001)
+	assert.InDelta(t, 20.0, total.WinRate, 0.0001)
+	assert.Equal(t, "2025-02-01T00:00:00Z", total.LastModified)
+}
+
+func Test_BRStatsModes_OverallOrMerged(t *testing.T) {
+	t.Parallel()
+
+	modes := BRStatsModes{
+		Solo:  BRStatsData{Wins: 1, Matches: 2},
+		Squad: BRStatsData{Wins: 2, Matches: 8},
+	}
+
+	merged := modes.OverallOrMerged()
+	assert.Equal(t, 3, merged.Wins)
+	assert.Equal(t, 10, merged.Matches)
+
+	modes.Overall = BRStatsData{Wins: 7, Matches: 70}
+	assert.Equal(t, 7, modes.OverallOrMerged().Wins)
+}
+
+func Test_CompareInputs(t *testing.T) {
+	t.Parallel()
+
+	stats := BRStatsStats{
+		KeyboardMouse: BRStatsKeyboardMouse{Overall: BRStatsData{Wins: 10, Matches: 100}},
+		Gamepad:       BRStatsGamepad{Overall: BRStatsData{Wins: 20, Matches: 100}},
+	}
+
+	comparison := stats.CompareInputs()
+	assert.Equal(t, []string{"keyboardMouse", "gamepad", "touch"}, comparison.Labels)
+
+	row, ok := comparison.Row(MetricWins)
+	require.True(t, ok)
+	assert.Equal(t, []float64{10, 20, 0}, row.Values)
+	assert.Equal(t, 1, row.Best)
+
+	row, ok = comparison.Row(MetricKills)
+	require.True(t, ok)
+	assert.Equal(t, -1, row.Best)
+}
+
+func Test_ComparePlayers(t *testing.T) {
+	t.Parallel()
+
+	a := testStats("a", "Alice", BRStatsData{Kills: 30, Deaths: 10, Matches: 10})
+	b := testStats("b", "Bob", BRStatsData{Kills: 10, Deaths: 10, Matches: 10})
+
+	comparison := ComparePlayers(&a, &b)
+	assert.Equal(t, []string{"Alice", "Bob"}, comparison.Labels)
+
+	row, ok := comparison.Row(MetricKD)
+	require.True(t, ok)
+	assert.Equal(t, 0, row.Best)
+}
+
+func Test_BRStatsData_AveragePlacement(t *testing.T) {
+	t.Parallel()
+
+	assert.Zero(t, BRStatsData{}.AveragePlacement())
+	assert.InDelta(t, 40.0, BRStatsData{Matches: 10, PlayersOutlived: 600}.AveragePlacement(), 0.0001)
+	assert.InDelta(t, 1.0, BRStatsData{Matches: 1, PlayersOutlived: 99}.AveragePlacement(), 0.0001)
+	assert.InDelta(t, 40.0, BRStatsData{Matches: 10, PlayersOutlived: 600}.Metric(MetricAveragePlacement), 0.0001)
+
+	a := testStats("a", "Alice", BRStatsData{Matches: 10, PlayersOutlived: 900})
+	b := testStats("b", "Bob", BRStatsData{Matches: 10, PlayersOutlived: 500})
+
+	row, ok := ComparePlayers(&a, &b).Row(MetricAveragePlacement)
+	require.True(t, ok)
+	assert.Equal(t, []float64{10, 50}, row.Values)
+	assert.Equal(t, 0, row.Best, "lower placement is better")
+
+	stats := BRStatsStats{Gamepad: BRStatsGamepad{Overall: BRStatsData{Matches: 10, PlayersOutlived: 500}}}
+
+	row, ok = stats.CompareInputs().Row(MetricAveragePlacement)
+	require.True(t, ok)
+	assert.Equal(t, 1, row.Best, "inputs without matches are never the best")
+}
@@ -0,0 +1,170 @@
+package fortniteapi
+
+import (
+	"context"
+	"sync"
+)
+
+type BRStatsLookup struct {
+	ID   string
+	Name string
+
+	// Enum: "epic", "psn", "xbl"
+	//
+	// Default: BRStatsBatchParams.AccountType
+	AccountType string
+}
+
+func BRStatsLookupsByName(names ...string) []BRStatsLookup {
+	lookups := make([]BRStatsLookup, len(names))
+	for i, name := range names {
+		lookups[i] = BRStatsLookup{Name: name}
+	}
+
+	return lookups
+}
+
+func BRStatsLookupsByID(ids ...string) []BRStatsLookup {
+	lookups := make([]BRStatsLookup, len(ids))
+	for i, id := range ids {
+		lookups[i] = BRStatsLookup{ID: id}
+	}
+
+	return lookups
+}
+
+type BRStatsBatchParams struct {
+	// Enum: "epic", "psn", "xbl"
+	//
+	// Default: "epic"
+	AccountType string
+
+	// Enum: "season", "lifetime"
+	//
+	// Default: "season"
+	TimeWindow string
+
+	// Enum: "all", "keyboardMouse", "gamepad", "touch"
+	//
+	// Default: *none*
+	Image         string
+	ResponseFlags ResponseFlag
+
+	// Concurrency is the number of requests in flight at once. Requests
+	// still go through the client's rate limiter.
+	//
+	// Default: 8
+	Concurrency int
+}
+
+type BRStatsBatchResult struct {
+	// Index is the position of the lookup in the input slice.
+	Index  int
+	Lookup BRStatsLookup
+	Stats  *BRStatsResponse
+	Err    error
+}
+
+// GetBRStatsBatch looks up every player and returns one result per
+// lookup, in input order. A failed lookup only sets the Err of its result.
+func (c *Client) GetBRStatsBatch(ctx context.Context, lookups []BRStatsLookup, params *BRStatsBatchParams) ([]BRStatsBatchResult, error) {
+	if err := c.checkAPIKey(); err != nil {
+		return nil, err
+	}
+
+	results := make([]BRStatsBatchResult, len(lookups))
+	done := make([]bool, len(lookups))
+
+	for result := range c.StreamBRStatsBatch(ctx, lookups, params) {
+		results[result.Index] = result
+		done[result.Index] = true
+	}
+
+	for i, lookup := range lookups {
+		if !done[i] {
+			results[i] = BRStatsBatchResult{Index: i, Lookup: lookup, Err: ctx.Err()}
+		}
+	}
+
+	return results, nil
+}
+
+// StreamBRStatsBatch looks up every player and sends each result as soon
+// as it completes. The channel is closed once all lookups are done or ctx
+// is cancelled.
+//
+// To stop reading before the channel is closed, cancel ctx. Otherwise the
+// lookups keep running and block on sending their results.
+func (c *Client) StreamBRStatsBatch(ctx context.Context, lookups []BRStatsLookup, params *BRStatsBatchParams) <-chan BRStatsBatchResult {
+	if params == nil {
+		params = &BRStatsBatchParams{}
+	}
+
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	jobs := make(chan int)
+	results := make(chan BRStatsBatchResult, concurrency)
+
+	var wg sync.WaitGroup
+
+	for range min(concurrency, max(len(lookups), 1)) {
+		wg.Go(func() {
+			for index := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+
+				stats, err := c.lookupBRStats(ctx, lookups[index], params)
+
+				select {
+				case results <- BRStatsBatchResult{Index: index, Lookup: lookups[index], Stats: stats, Err: err}:
+				case <-ctx.Done():
+				}
+			}
+		})
+	}
+
+	go func() {
+		defer close(jobs)
+
+		for index := range lookups {
+			select {
+			case jobs <- index:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+func (c *Client) lookupBRStats(ctx context.Context, lookup BRStatsLookup, params *BRStatsBatchParams) (*BRStatsResponse, error) {
+	if lookup.ID != "" {
+		return c.GetBRStatsByID(ctx, lookup.ID, &BRStatsByIDParams{
+			TimeWindow:    params.TimeWindow,
+			Image:         params.Image,
+			ResponseFlags: params.ResponseFlags,
+		})
+	}
+
+	accountType := lookup.AccountType
+	if accountType == "" {
+		accountType = params.AccountType
+	}
+
+	return c.GetBRStatsByName(ctx, lookup.Name, &BRStatsByNameParams{
+		AccountType:   accountType,
+		TimeWindow:    params.TimeWindow,
+		Image:         params.Image,
+		ResponseFlags: params.ResponseFlags,
+	})
+}
@@ -0,0 +1,102 @@
+package fortniteapi
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetBRStatsBatch(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "Alice", BRStatsData{Wins: 1}))
+	server.set(testStats("id2", "Bob", BRStatsData{Wins: 2}))
+
+	client := newTestStatsClient(t, server.handle(t))
+
+	lookups := append(BRStatsLookupsByName("alice", "private"), BRStatsLookupsByID("id2")...)
+
+	results, err := client.GetBRStatsBatch(testCtx, lookups, &BRStatsBatchParams{Concurrency: 2})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "id1", results[0].Stats.Account.ID)
+
+	require.ErrorIs(t, results[1].Err, ErrNotFound)
+	assert.Equal(t, "private", results[1].Lookup.Name)
+
+	require.NoError(t, results[2].Err)
+	assert.Equal(t, 2, results[2].Stats.Stats.All.Overall.Wins)
+}
+
+func Test_StreamBRStatsBatch_BoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, peak atomic.Int32
+
+	client := newTestStatsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		writeTestData(t, w, testStats(r.URL.Query().Get("name"), "", BRStatsData{}))
+	})
+
+	lookups := BRStatsLookupsByName("a", "b", "c", "d", "e", "f")
+
+	count := 0
+	for result := range client.StreamBRStatsBatch(testCtx, lookups, &BRStatsBatchParams{Concurrency: 2}) {
+		require.NoError(t, result.Err)
+		count++
+	}
+
+	assert.Equal(t, len(lookups), count)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func Test_StreamBRStatsBatch_Abandon(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	client := newTestStatsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeTestData(t, w, testStats(r.URL.Query().Get("name"), "", BRStatsData{}))
+	})
+
+	lookups := BRStatsLookupsByName("a", "b", "c", "d", "e", "f", "g", "h")
+
+	ctx, cancel := context.WithCancel(testCtx)
+	results := client.StreamBRStatsBatch(ctx, lookups, &BRStatsBatchParams{Concurrency: 1})
+
+	<-results
+	cancel()
+
+	// The stream closes soon after the cancellation, before every lookup ran.
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-results:
+			if !ok {
+				assert.Less(t, requests.Load(), int32(len(lookups)))
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the stream to close")
+		}
+	}
+}
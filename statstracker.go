@@ -0,0 +1,354 @@
+package fortniteapi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sub returns the difference between d and an earlier value of the same
+// counters, with ratios computed from the difference. When a counter went
+// down, as it does after a season reset, d is returned as is.
+func (d BRStatsData) Sub(previous BRStatsData) BRStatsData {
+	if d.countersDropped(previous) {
+		return d
+	}
+
+	delta := BRStatsData{
+		Score:           d.Score - previous.Score,
+		Wins:            d.Wins - previous.Wins,
+		Top3:            d.Top3 - previous.Top3,
+		Top5:            d.Top5 - previous.Top5,
+		Top6:            d.Top6 - previous.Top6,
+		Top10:           d.Top10 - previous.Top10,
+		Top12:           d.Top12 - previous.Top12,
+		Top25:           d.Top25 - previous.Top25,
+		Kills:           d.Kills - previous.Kills,
+		Deaths:          d.Deaths - previous.Deaths,
+		Matches:         d.Matches - previous.Matches,
+		MinutesPlayed:   d.MinutesPlayed - previous.MinutesPlayed,
+		PlayersOutlived: d.PlayersOutlived - previous.PlayersOutlived,
+		LastModified:    d.LastModified,
+	}
+
+	delta.recomputeRatios()
+	return delta
+}
+
+func (d BRStatsData) countersDropped(previous BRStatsData) bool {
+	return d.Matches < previous.Matches ||
+		d.Wins < previous.Wins ||
+		d.Kills < previous.Kills ||
+		d.Deaths < previous.Deaths ||
+		d.Score < previous.Score ||
+		d.MinutesPlayed < previous.MinutesPlayed ||
+		d.Top3 < previous.Top3 ||
+		d.Top5 < previous.Top5 ||
+		d.Top6 < previous.Top6 ||
+		d.Top10 < previous.Top10 ||
+		d.Top12 < previous.Top12 ||
+		d.Top25 < previous.Top25 ||
+		d.PlayersOutlived < previous.PlayersOutlived
+}
+
+func (d *BRStatsData) recomputeRatios() {
+	d.ScorePerMin = ratio(d.Score, d.MinutesPlayed)
+	d.ScorePerMatch = ratio(d.Score, d.Matches)
+	d.KillsPerMin = ratio(d.Kills, d.MinutesPlayed)
+	d.KillsPerMatch = ratio(d.Kills, d.Matches)
+	d.WinRate = ratio(d.Wins, d.Matches) * 100
+
+	// The API reports kills as the K/D when there are no deaths.
+	if d.Deaths == 0 {
+		d.KD = float64(d.Kills)
+	} else {
+		d.KD = ratio(d.Kills, d.Deaths)
+	}
+}
+
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+
+	return float64(a) / float64(b)
+}
+
+func (m BRStatsModes) Sub(previous BRStatsModes) BRStatsModes {
+	return BRStatsModes{
+		Overall: m.Overall.Sub(previous.Overall),
+		Solo:    m.Solo.Sub(previous.Solo),
+		Duo:     m.Duo.Sub(previous.Duo),
+		Trio:    m.Trio.Sub(previous.Trio),
+		Squad:   m.Squad.Sub(previous.Squad),
+		LTM:     m.LTM.Sub(previous.LTM),
+	}
+}
+
+func (m BRStatsModes) countersDropped(previous BRStatsModes) bool {
+	return m.Overall.countersDropped(previous.Overall) ||
+		m.Solo.countersDropped(previous.Solo) ||
+		m.Duo.countersDropped(previous.Duo) ||
+		m.Trio.countersDropped(previous.Trio) ||
+		m.Squad.countersDropped(previous.Squad) ||
+		m.LTM.countersDropped(previous.LTM)
+}
+
+func (s BRStatsStats) Sub(previous BRStatsStats) BRStatsStats {
+	return BRStatsStats{
+		All:           BRStatsAll(BRStatsModes(s.All).Sub(BRStatsModes(previous.All))),
+		KeyboardMouse: BRStatsKeyboardMouse(BRStatsModes(s.KeyboardMouse).Sub(BRStatsModes(previous.KeyboardMouse))),
+		Gamepad:       BRStatsGamepad(BRStatsModes(s.Gamepad).Sub(BRStatsModes(previous.Gamepad))),
+		Touch:         BRStatsTouch(BRStatsModes(s.Touch).Sub(BRStatsModes(previous.Touch))),
+	}
+}
+
+func (s BRStatsStats) countersDropped(previous BRStatsStats) bool {
+	return BRStatsModes(s.All).countersDropped(BRStatsModes(previous.All)) ||
+		BRStatsModes(s.KeyboardMouse).countersDropped(BRStatsModes(previous.KeyboardMouse)) ||
+		BRStatsModes(s.Gamepad).countersDropped(BRStatsModes(previous.Gamepad)) ||
+		BRStatsModes(s.Touch).countersDropped(BRStatsModes(previous.Touch))
+}
+
+type StatsSnapshot struct {
+	AccountID  string            `json:"accountId"`
+	Name       string            `json:"name"`
+	TakenAt    time.Time         `json:"takenAt"`
+	BattlePass BRStatsBattlePass `json:"battlePass"`
+	Stats      BRStatsStats      `json:"stats"`
+}
+
+type StatsDelta struct {
+	AccountID string
+	Name      string
+	From      time.Time
+	To        time.Time
+
+	// Reset is true when at least one counter went down between the two
+	// snapshots, in which case the affected entries count from zero.
+	Reset bool
+	Stats BRStatsStats
+}
+
+// StatsSnapshotStore persists snapshots. LatestSnapshot returns nil
+// without an error when the account has no snapshot yet.
+type StatsSnapshotStore interface {
+	LatestSnapshot(ctx context.Context, accountID string) (*StatsSnapshot, error)
+	SaveSnapshot(ctx context.Context, snapshot *StatsSnapshot) error
+}
+
+type MemoryStatsStore struct {
+	mu        sync.RWMutex
+	snapshots map[string][]StatsSnapshot
+}
+
+func NewMemoryStatsStore() *MemoryStatsStore {
+	return &MemoryStatsStore{
+		snapshots: make(map[string][]StatsSnapshot),
+	}
+}
+
+func (m *MemoryStatsStore) LatestSnapshot(_ context.Context, accountID string) (*StatsSnapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshots := m.snapshots[accountID]
+	if len(snapshots) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	latest := snapshots[len(snapshots)-1]
+	return &latest, nil
+}
+
+func (m *MemoryStatsStore) SaveSnapshot(_ context.Context, snapshot *StatsSnapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.snapshots[snapshot.AccountID] = append(m.snapshots[snapshot.AccountID], *snapshot)
+	return nil
+}
+
+// History returns every snapshot of the account, oldest first.
+func (m *MemoryStatsStore) History(accountID string) []StatsSnapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return slices.Clone(m.snapshots[accountID])
+}
+
+// TrackedAccount identifies a player by ID, or by name and account type
+// until the first lookup resolves the ID.
+type TrackedAccount struct {
+	ID   string
+	Name string
+
+	// Enum: "epic", "psn", "xbl"
+	//
+	// Default: "epic"
+	AccountType string
+}
+
+type StatsTrackerOptions struct {
+	// Enum: "season", "lifetime"
+	//
+	// Default: "season"
+	TimeWindow string
+
+	// Interval is the time between two snapshots in Run.
+	//
+	// Default: 15 minutes
+	Interval time.Duration
+
+	// Default: an in-memory store
+	Store StatsSnapshotStore
+
+	Clock   Clock
+	OnError func(error)
+}
+
+type StatsTracker struct {
+	client  *Client
+	options StatsTrackerOptions
+
+	mu       sync.Mutex
+	accounts []TrackedAccount
+}
+
+func NewStatsTracker(client *Client, options StatsTrackerOptions) *StatsTracker {
+	if options.Interval <= 0 {
+		options.Interval = 15 * time.Minute
+	}
+
+	if options.Store == nil {
+		options.Store = NewMemoryStatsStore()
+	}
+
+	if options.Clock == nil {
+		options.Clock = systemClock{}
+	}
+
+	return &StatsTracker{
+		client:  client,
+		options: options,
+	}
+}
+
+func (t *StatsTracker) Track(accounts ...TrackedAccount) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accounts = append(t.accounts, accounts...)
+}
+
+// Untrack stops tracking the account with the given ID or name.
+func (t *StatsTracker) Untrack(idOrName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.accounts = slices.DeleteFunc(t.accounts, func(account TrackedAccount) bool {
+		return account.ID == idOrName || strings.EqualFold(account.Name, idOrName)
+	})
+}
+
+func (t *StatsTracker) Accounts() []TrackedAccount {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Clone(t.accounts)
+}
+
+// Poll snapshots every tracked account and returns the deltas against the
+// previous snapshots. Accounts without a previous snapshot produce no
+// delta. Failures of single accounts are joined into the returned error.
+func (t *StatsTracker) Poll(ctx context.Context) ([]StatsDelta, error) {
+	var (
+		deltas []StatsDelta
+		errs   []error
+	)
+
+	for i, account := range t.Accounts() {
+		stats, err := t.fetch(ctx, account)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if account.ID == "" {
+			t.resolve(i, account, stats.Account.ID)
+		}
+
+		delta, err := t.snapshot(ctx, stats)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if delta != nil {
+			deltas = append(deltas, *delta)
+		}
+	}
+
+	return deltas, errors.Join(errs...)
+}
+
+func (t *StatsTracker) Run(ctx context.Context, handler func(StatsDelta)) error {
+	return runPolls(ctx, t.options.Clock, t.Poll, every(t.options.Interval), t.options.OnError, handler)
+}
+
+func (t *StatsTracker) fetch(ctx context.Context, account TrackedAccount) (*BRStatsResponse, error) {
+	if account.ID != "" {
+		return t.client.GetBRStatsByID(ctx, account.ID, &BRStatsByIDParams{TimeWindow: t.options.TimeWindow})
+	}
+
+	return t.client.GetBRStatsByName(ctx, account.Name, &BRStatsByNameParams{
+		AccountType: account.AccountType,
+		TimeWindow:  t.options.TimeWindow,
+	})
+}
+
+// resolve stores the account ID after a lookup by name, so later polls
+// keep working when the player renames.
+func (t *StatsTracker) resolve(index int, account TrackedAccount, id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if index < len(t.accounts) && t.accounts[index] == account {
+		t.accounts[index].ID = id
+	}
+}
+
+func (t *StatsTracker) snapshot(ctx context.Context, stats *BRStatsResponse) (*StatsDelta, error) {
+	current := &StatsSnapshot{
+		AccountID:  stats.Account.ID,
+		Name:       stats.Account.Name,
+		TakenAt:    t.options.Clock.Now(),
+		BattlePass: stats.BattlePass,
+		Stats:      stats.Stats,
+	}
+
+	previous, err := t.options.Store.LatestSnapshot(ctx, current.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.options.Store.SaveSnapshot(ctx, current); err != nil {
+		return nil, err
+	}
+
+	if previous == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &StatsDelta{
+		AccountID: current.AccountID,
+		Name:      current.Name,
+		From:      previous.TakenAt,
+		To:        current.TakenAt,
+		Reset:     current.Stats.countersDropped(previous.Stats),
+		Stats:     current.Stats.Sub(previous.Stats),
+	}, nil
+}
@@ -0,0 +1,168 @@
+package fortniteapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStatsClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewClient(LanguageEnglish, "test-key", WithBaseURL(server.URL))
+}
+
+type fakeStatsServer struct {
+	mu       sync.Mutex
+	accounts map[string]BRStatsResponse
+	byName   int
+}
+
+func newFakeStatsServer() *fakeStatsServer {
+	return &fakeStatsServer{accounts: map[string]BRStatsResponse{}}
+}
+
+func (f *fakeStatsServer) set(stats BRStatsResponse) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.accounts[stats.Account.ID] = stats
+}
+
+func (f *fakeStatsServer) lookupsByName() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.byName
+}
+
+func (f *fakeStatsServer) handle(t *testing.T) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if id, ok := strings.CutPrefix(r.URL.Path, "/v2/stats/br/v2/"); ok {
+			if stats, ok := f.accounts[id]; ok {
+				writeTestData(t, w, stats)
+				return
+			}
+		}
+
+		name := r.URL.Query().Get("name")
+		for _, stats := range f.accounts {
+			if name != "" && strings.EqualFold(stats.Account.Name, name) {
+				f.byName++
+				writeTestData(t, w, stats)
+
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"status":404,"error":"the requested account does not exist"}`))
+	}
+}
+
+func testStats(id, name string, overall BRStatsData) BRStatsResponse {
+	return BRStatsResponse{
+		Account: BRStatsAccount{ID: id, Name: name},
+		Stats:   BRStatsStats{All: BRStatsAll{Overall: overall}},
+	}
+}
+
+func Test_BRStatsData_Sub(t *testing.T) {
+	t.Parallel()
+
+	previous := BRStatsData{Wins: 2, Kills: 10, Deaths: 8, Matches: 10, MinutesPlayed: 100}
+	current := BRStatsData{Wins: 3, Kills: 16, Deaths: 10, Matches: 12, MinutesPlayed: 130}
+
+	delta := current.Sub(previous)
+	assert.Equal(t, 1, delta.Wins)
+	assert.Equal(t, 6, delta.Kills)
+	assert.Equal(t, 2, delta.Matches)
+	assert.InDelta(t, 3.0, delta.KD, 0.0001)
+	assert.InDelta(t, 50.0, delta.WinRate, 0.0001)
+	assert.InDelta(t, 0.2, delta.KillsPerMin, 0.0001)
+}
+
+func Test_BRStatsData_SubAfterReset(t *testing.T) {
+	t.Parallel()
+
+	previous := BRStatsData{Wins: 20, Kills: 100, Matches: 80}
+	current := BRStatsData{Wins: 1, Kills: 4, Matches: 3}
+
+	assert.Equal(t, current, current.Sub(previous))
+}
+
+func Test_BRStatsData_SubAfterPartialReset(t *testing.T) {
+	t.Parallel()
+
+	previous := BRStatsData{Wins: 2, Kills: 10, Matches: 8, Top10: 5, PlayersOutlived: 300}
+	current := BRStatsData{Wins: 3, Kills: 12, Matches: 9, Top10: 1, PlayersOutlived: 40}
+
+	assert.Equal(t, current, current.Sub(previous))
+}
+
+func Test_StatsTracker_Poll(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "Player", BRStatsData{Wins: 1, Kills: 5, Matches: 4}))
+
+	clock := newFakeClock(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC))
+	store := NewMemoryStatsStore()
+
+	tracker := NewStatsTracker(newTestStatsClient(t, server.handle(t)), StatsTrackerOptions{Store: store, Clock: clock})
+	tracker.Track(TrackedAccount{Name: "player"})
+
+	deltas, err := tracker.Poll(testCtx)
+	require.NoError(t, err)
+	assert.Empty(t, deltas)
+	assert.Equal(t, "id1", tracker.Accounts()[0].ID)
+
+	server.set(testStats("id1", "Renamed", BRStatsData{Wins: 2, Kills: 9, Matches: 6}))
+
+	deltas, err = tracker.Poll(testCtx)
+	require.NoError(t, err)
+	require.Len(t, deltas, 1)
+	assert.False(t, deltas[0].Reset)
+	assert.Equal(t, "Renamed", deltas[0].Name)
+	assert.Equal(t, 1, deltas[0].Stats.All.Overall.Wins)
+	assert.Equal(t, 4, deltas[0].Stats.All.Overall.Kills)
+	assert.Equal(t, 1, server.lookupsByName())
+
+	server.set(testStats("id1", "Renamed", BRStatsData{Kills: 1, Matches: 1}))
+
+	deltas, err = tracker.Poll(testCtx)
+	require.NoError(t, err)
+	require.Len(t, deltas, 1)
+	assert.True(t, deltas[0].Reset)
+	assert.Equal(t, 1, deltas[0].Stats.All.Overall.Kills)
+
+	assert.Len(t, store.History("id1"), 3)
+}
+
+func Test_StatsTracker_PollKeepsGoingOnErrors(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeStatsServer()
+	server.set(testStats("id1", "Player", BRStatsData{}))
+
+	tracker := NewStatsTracker(newTestStatsClient(t, server.handle(t)), StatsTrackerOptions{})
+	tracker.Track(TrackedAccount{Name: "missing"}, TrackedAccount{ID: "id1"})
+
+	_, err := tracker.Poll(testCtx)
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Len(t, tracker.Accounts(), 2)
+}